@@ -0,0 +1,310 @@
+package autograd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/obarker94/ml-doe/internal"
+	"github.com/obarker94/ml-doe/internal/network"
+)
+
+// Tape records tensor operations in the order they run. Because every
+// operation can only consume tensors that already exist, replaying the tape in
+// reverse is a valid topological order for backpropagation.
+type Tape struct {
+	nodes []*Tensor
+}
+
+func NewTape() *Tape {
+	return &Tape{}
+}
+
+// Tensor is a matrix of values with a matching gradient buffer. Vectors are
+// stored as a single row, the same layout network.Param uses.
+type Tensor struct {
+	Data [][]float64
+	Grad [][]float64
+
+	tape     *Tape
+	index    int
+	backward func()
+}
+
+// Vector records a leaf tensor holding a copy of v.
+func (tp *Tape) Vector(v []float64) (*Tensor, error) {
+	if len(v) == 0 {
+		return nil, fmt.Errorf("input vector must have length")
+	}
+
+	return tp.record([][]float64{append([]float64(nil), v...)}), nil
+}
+
+// Matrix records a leaf tensor holding a copy of m, which must be rectangular.
+func (tp *Tape) Matrix(m [][]float64) (*Tensor, error) {
+	if err := checkRectangular(m); err != nil {
+		return nil, err
+	}
+
+	data := make([][]float64, len(m))
+	for idx, row := range m {
+		data[idx] = append([]float64(nil), row...)
+	}
+
+	return tp.record(data), nil
+}
+
+// FromParam records a leaf tensor that shares storage with p, so Backward
+// accumulates straight into a layer's gradient buffers.
+func (tp *Tape) FromParam(p network.Param) (*Tensor, error) {
+	if err := checkRectangular(p.Value); err != nil {
+		return nil, errors.Join(fmt.Errorf("param %s is invalid", p.Name), err)
+	}
+	if len(p.Grad) != len(p.Value) {
+		return nil, fmt.Errorf("param %s has %d value rows and %d grad rows", p.Name, len(p.Value), len(p.Grad))
+	}
+
+	t := &Tensor{Data: p.Value, Grad: p.Grad, tape: tp, index: len(tp.nodes)}
+	tp.nodes = append(tp.nodes, t)
+
+	return t, nil
+}
+
+// MatVecMul records W x for a matrix W and a vector x.
+func (tp *Tape) MatVecMul(W, x *Tensor) (*Tensor, error) {
+	if err := x.checkVector(); err != nil {
+		return nil, errors.Join(errors.New("MatVecMul x"), err)
+	}
+
+	y, err := internal.MatVecMul(W.Data, x.Data[0])
+	if err != nil {
+		return nil, err
+	}
+
+	out := tp.record([][]float64{y})
+	out.backward = func() {
+		for o, row := range W.Data {
+			for i, w := range row {
+				W.Grad[o][i] += out.Grad[0][o] * x.Data[0][i]
+				x.Grad[0][i] += w * out.Grad[0][o]
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// AddVec records v + b for two vectors of equal length.
+func (tp *Tape) AddVec(v, b *Tensor) (*Tensor, error) {
+	if err := v.checkVector(); err != nil {
+		return nil, errors.Join(errors.New("AddVec v"), err)
+	}
+	if err := b.checkVector(); err != nil {
+		return nil, errors.Join(errors.New("AddVec b"), err)
+	}
+
+	y, err := internal.AddVec(v.Data[0], b.Data[0])
+	if err != nil {
+		return nil, err
+	}
+
+	out := tp.record([][]float64{y})
+	out.backward = func() {
+		for i, g := range out.Grad[0] {
+			v.Grad[0][i] += g
+			b.Grad[0][i] += g
+		}
+	}
+
+	return out, nil
+}
+
+// Shift records v + s for a constant s.
+func (tp *Tape) Shift(v *Tensor, s float64) (*Tensor, error) {
+	if err := v.checkVector(); err != nil {
+		return nil, errors.Join(errors.New("Shift v"), err)
+	}
+
+	y, err := network.Shift(v.Data[0], s)
+	if err != nil {
+		return nil, err
+	}
+
+	out := tp.record([][]float64{y})
+	out.backward = func() {
+		for i, g := range out.Grad[0] {
+			v.Grad[0][i] += g
+		}
+	}
+
+	return out, nil
+}
+
+// ReLU records network.ReLU applied to v.
+func (tp *Tape) ReLU(v *Tensor) (*Tensor, error) {
+	if err := v.checkVector(); err != nil {
+		return nil, errors.Join(errors.New("ReLU v"), err)
+	}
+
+	y, err := network.ReLU{}.Apply(v.Data[0])
+	if err != nil {
+		return nil, err
+	}
+
+	out := tp.record([][]float64{y})
+	out.backward = func() {
+		dv, _ := network.ReLU{}.Backward(v.Data[0], out.Grad[0])
+		for i, g := range dv {
+			v.Grad[0][i] += g
+		}
+	}
+
+	return out, nil
+}
+
+// Softmax records network.SoftmaxWithStats applied to v and passes the stats
+// through.
+func (tp *Tape) Softmax(v *Tensor) (*Tensor, network.SoftmaxStats, error) {
+	if err := v.checkVector(); err != nil {
+		return nil, network.SoftmaxStats{}, errors.Join(errors.New("Softmax v"), err)
+	}
+
+	p, stats, err := network.SoftmaxWithStats(v.Data[0])
+	if err != nil {
+		return nil, network.SoftmaxStats{}, err
+	}
+
+	out := tp.record([][]float64{p})
+	out.backward = func() {
+		// dv_i = p_i * (dp_i - sum_j p_j dp_j)
+		dot := 0.0
+		for j, g := range out.Grad[0] {
+			dot += p[j] * g
+		}
+		for i, g := range out.Grad[0] {
+			v.Grad[0][i] += p[i] * (g - dot)
+		}
+	}
+
+	return out, stats, nil
+}
+
+// CrossEntropy records the scalar negative log-likelihood of target under
+// softmax(z), computed via network.LogSoftmax.
+func (tp *Tape) CrossEntropy(z *Tensor, target int) (*Tensor, error) {
+	if err := z.checkVector(); err != nil {
+		return nil, errors.Join(errors.New("CrossEntropy z"), err)
+	}
+
+	logP, err := network.LogSoftmax(z.Data[0])
+	if err != nil {
+		return nil, err
+	}
+
+	loss, err := network.NLLLoss(logP, target)
+	if err != nil {
+		return nil, err
+	}
+
+	out := tp.record([][]float64{{loss}})
+	out.backward = func() {
+		for i, lp := range logP {
+			g := math.Exp(lp)
+			if i == target {
+				g--
+			}
+			z.Grad[0][i] += g * out.Grad[0][0]
+		}
+	}
+
+	return out, nil
+}
+
+// Dot records the scalar inner product of two vectors.
+func (tp *Tape) Dot(a, b *Tensor) (*Tensor, error) {
+	if err := a.checkVector(); err != nil {
+		return nil, errors.Join(errors.New("Dot a"), err)
+	}
+	if err := b.checkVector(); err != nil {
+		return nil, errors.Join(errors.New("Dot b"), err)
+	}
+
+	d, err := internal.Dot(a.Data[0], b.Data[0])
+	if err != nil {
+		return nil, err
+	}
+
+	out := tp.record([][]float64{{d}})
+	out.backward = func() {
+		g := out.Grad[0][0]
+		for i := range a.Data[0] {
+			a.Grad[0][i] += b.Data[0][i] * g
+			b.Grad[0][i] += a.Data[0][i] * g
+		}
+	}
+
+	return out, nil
+}
+
+// Backward populates the gradients of every tensor recorded before t on its
+// tape. t must be a scalar. Gradients of intermediate tensors are reset first,
+// so calling Backward again adds the same gradient to the leaves once more.
+func (t *Tensor) Backward() error {
+	if len(t.Data) != 1 || len(t.Data[0]) != 1 {
+		return fmt.Errorf("backward requires a scalar tensor, got shape %dx%d", len(t.Data), len(t.Data[0]))
+	}
+
+	for _, node := range t.tape.nodes[:t.index+1] {
+		if node.backward == nil {
+			continue
+		}
+		for _, row := range node.Grad {
+			clear(row)
+		}
+	}
+
+	t.Grad[0][0] = 1
+	for i := t.index; i >= 0; i-- {
+		if node := t.tape.nodes[i]; node.backward != nil {
+			node.backward()
+		}
+	}
+
+	return nil
+}
+
+// Vec returns the data of a vector tensor.
+func (t *Tensor) Vec() []float64 {
+	return t.Data[0]
+}
+
+func (tp *Tape) record(data [][]float64) *Tensor {
+	grad := make([][]float64, len(data))
+	for idx, row := range data {
+		grad[idx] = make([]float64, len(row))
+	}
+
+	t := &Tensor{Data: data, Grad: grad, tape: tp, index: len(tp.nodes)}
+	tp.nodes = append(tp.nodes, t)
+
+	return t
+}
+
+func (t *Tensor) checkVector() error {
+	if len(t.Data) != 1 {
+		return fmt.Errorf("expected a vector, got %d rows", len(t.Data))
+	}
+	return nil
+}
+
+func checkRectangular(m [][]float64) error {
+	if len(m) == 0 || len(m[0]) == 0 {
+		return fmt.Errorf("matrix must have at least one row and column")
+	}
+	for idx, row := range m {
+		if len(row) != len(m[0]) {
+			return fmt.Errorf("shape is not rectangular: row %d has length %d, expected %d", idx, len(row), len(m[0]))
+		}
+	}
+	return nil
+}
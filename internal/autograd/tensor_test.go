@@ -0,0 +1,214 @@
+package autograd
+
+import (
+	"testing"
+
+	"github.com/obarker94/ml-doe/internal/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func testMLP() *network.MLP {
+	return &network.MLP{
+		Hidden: network.Block{
+			LinearLayer: network.LinearLayer{
+				In:  3,
+				Out: 4,
+				W:   [][]float64{{0.4, -0.2, 0.1}, {-0.1, 0.2, 0.3}, {0.5, 0.3, -0.4}, {0.2, -0.6, 0.1}},
+				B:   []float64{0.1, 0.2, -0.1, 0.05},
+			},
+			Nonlinearity: network.ReLU{},
+		},
+		Out: network.LinearLayer{
+			In:  4,
+			Out: 3,
+			W:   [][]float64{{0.4, 0.2, -0.1, 0.4}, {-0.1, 0.2, 0.3, 0.2}, {0.4, -0.2, 0.1, 0.5}},
+			B:   []float64{0.4, 0.7, 1.2},
+		},
+	}
+}
+
+// forwardMLP records the MLP forward pass and cross-entropy on tp, with the
+// tape's leaves sharing storage with the MLP's params.
+func forwardMLP(t *testing.T, tp *Tape, mlp *network.MLP, x *Tensor, target int) *Tensor {
+	leaves := make([]*Tensor, 0)
+	for _, p := range mlp.Params() {
+		leaf, err := tp.FromParam(p)
+		assert.NoError(t, err)
+		leaves = append(leaves, leaf)
+	}
+	w1, b1, w2, b2 := leaves[0], leaves[1], leaves[2], leaves[3]
+
+	h, err := tp.MatVecMul(w1, x)
+	assert.NoError(t, err)
+	h, err = tp.AddVec(h, b1)
+	assert.NoError(t, err)
+	h, err = tp.ReLU(h)
+	assert.NoError(t, err)
+	z, err := tp.MatVecMul(w2, h)
+	assert.NoError(t, err)
+	z, err = tp.AddVec(z, b2)
+	assert.NoError(t, err)
+	loss, err := tp.CrossEntropy(z, target)
+	assert.NoError(t, err)
+
+	return loss
+}
+
+func TestTape(t *testing.T) {
+	t.Run("MLP gradients match hand-written Backward", func(t *testing.T) {
+		// Arrange
+		input := []float64{0.9, -0.8, 0.3}
+		target := 1
+
+		reference := testMLP()
+		z, err := reference.Forward(input)
+		assert.NoError(t, err)
+		ce, err := network.BatchCrossEntropy([][]float64{z}, []int{target}, network.CrossEntropyOptions{})
+		assert.NoError(t, err)
+		dx, err := reference.Backward(ce.Grad[0])
+		assert.NoError(t, err)
+
+		mlp := testMLP()
+		network.ZeroGrad(mlp.Params())
+		tp := NewTape()
+		x, err := tp.Vector(input)
+		assert.NoError(t, err)
+
+		// Act
+		loss := forwardMLP(t, tp, mlp, x, target)
+		err = loss.Backward()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, ce.Loss, loss.Data[0][0], 1e-12)
+		assert.InDeltaSlice(t, dx, x.Grad[0], 1e-12)
+		want, got := reference.Params(), mlp.Params()
+		for i := range want {
+			for r := range want[i].Grad {
+				assert.InDeltaSlice(t, want[i].Grad[r], got[i].Grad[r], 1e-12, want[i].Name)
+			}
+		}
+	})
+
+	t.Run("Shift, Softmax and Dot gradients match finite differences", func(t *testing.T) {
+		// Arrange
+		v := []float64{0.3, -1.1, 0.7}
+		w := []float64{1.0, -2.0, 0.5}
+		grad := make([]float64, len(v))
+		params := []network.Param{{Name: "v", Value: [][]float64{v}, Grad: [][]float64{grad}}}
+
+		build := func() (*Tensor, error) {
+			tp := NewTape()
+			vt, err := tp.FromParam(params[0])
+			if err != nil {
+				return nil, err
+			}
+			wt, err := tp.Vector(w)
+			if err != nil {
+				return nil, err
+			}
+			s, err := tp.Shift(vt, 2.5)
+			if err != nil {
+				return nil, err
+			}
+			p, _, err := tp.Softmax(s)
+			if err != nil {
+				return nil, err
+			}
+			return tp.Dot(p, wt)
+		}
+		loss := func() (float64, error) {
+			out, err := build()
+			if err != nil {
+				return 0, err
+			}
+			return out.Data[0][0], nil
+		}
+		backward := func() error {
+			out, err := build()
+			if err != nil {
+				return err
+			}
+			return out.Backward()
+		}
+
+		// Act
+		report, err := network.GradCheckLoss(params, loss, backward, network.GradCheckConfig{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-6))
+	})
+
+	t.Run("Softmax passes through stats", func(t *testing.T) {
+		// Arrange
+		tp := NewTape()
+		v, err := tp.Vector([]float64{0, 0})
+		assert.NoError(t, err)
+
+		// Act
+		p, stats, err := tp.Softmax(v)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{0.5, 0.5}, p.Vec(), 1e-12)
+		assert.InDelta(t, 0.5, stats.MaxProb, 1e-12)
+	})
+
+	t.Run("calling Backward twice doubles the leaf gradients", func(t *testing.T) {
+		// Arrange: loss = (a+1)·b with a = 2, b = 3.
+		tp := NewTape()
+		a, err := tp.Vector([]float64{2})
+		assert.NoError(t, err)
+		b, err := tp.Vector([]float64{3})
+		assert.NoError(t, err)
+		shifted, err := tp.Shift(a, 1)
+		assert.NoError(t, err)
+		loss, err := tp.Dot(shifted, b)
+		assert.NoError(t, err)
+
+		// Act
+		assert.NoError(t, loss.Backward())
+		first := a.Grad[0][0]
+		assert.NoError(t, loss.Backward())
+
+		// Assert
+		assert.Equal(t, 3.0, first)
+		assert.Equal(t, 6.0, a.Grad[0][0])
+		assert.Equal(t, 6.0, b.Grad[0][0])
+	})
+
+	t.Run("Backward requires a scalar", func(t *testing.T) {
+		// Arrange
+		tp := NewTape()
+		v, err := tp.Vector([]float64{1, 2})
+		assert.NoError(t, err)
+
+		// Act
+		err = v.Backward()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("ops reject mismatched shapes", func(t *testing.T) {
+		// Arrange
+		tp := NewTape()
+		W, err := tp.Matrix([][]float64{{1, 2}, {3, 4}})
+		assert.NoError(t, err)
+		x, err := tp.Vector([]float64{1, 2, 3})
+		assert.NoError(t, err)
+
+		// Act
+		_, errMul := tp.MatVecMul(W, x)
+		_, errAdd := tp.AddVec(W, x)
+		_, errRagged := tp.Matrix([][]float64{{1}, {1, 2}})
+		_, errEmpty := tp.Vector([]float64{})
+
+		// Assert
+		assert.Error(t, errMul)
+		assert.Error(t, errAdd)
+		assert.Error(t, errRagged)
+		assert.Error(t, errEmpty)
+	})
+}
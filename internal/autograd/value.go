@@ -0,0 +1,166 @@
+package autograd
+
+import (
+	"fmt"
+	"math"
+)
+
+// Value is a scalar node in a computation graph. Operations on Values build
+// the graph as they go, and Backward fills in Grad for every node that
+// contributed to the result.
+//
+// Like the layers in the network package, gradients accumulate: reuse leaf
+// Values across steps only after ZeroGrad.
+type Value struct {
+	Data float64
+	Grad float64
+
+	op       string
+	children []*Value
+	backward func()
+}
+
+// NewValue creates a leaf Value.
+func NewValue(data float64) *Value {
+	return &Value{Data: data}
+}
+
+// Values wraps each element of xs in a leaf Value.
+func Values(xs []float64) []*Value {
+	output := make([]*Value, len(xs))
+	for idx, x := range xs {
+		output[idx] = NewValue(x)
+	}
+	return output
+}
+
+func (v *Value) String() string {
+	return fmt.Sprintf("Value(data=%g, grad=%g)", v.Data, v.Grad)
+}
+
+func (v *Value) Add(o *Value) *Value {
+	out := &Value{Data: v.Data + o.Data, op: "+", children: []*Value{v, o}}
+	out.backward = func() {
+		v.Grad += out.Grad
+		o.Grad += out.Grad
+	}
+	return out
+}
+
+func (v *Value) Mul(o *Value) *Value {
+	out := &Value{Data: v.Data * o.Data, op: "*", children: []*Value{v, o}}
+	out.backward = func() {
+		v.Grad += o.Data * out.Grad
+		o.Grad += v.Data * out.Grad
+	}
+	return out
+}
+
+// Pow raises v to a constant power.
+func (v *Value) Pow(k float64) *Value {
+	out := &Value{Data: math.Pow(v.Data, k), op: "pow", children: []*Value{v}}
+	out.backward = func() {
+		v.Grad += k * math.Pow(v.Data, k-1) * out.Grad
+	}
+	return out
+}
+
+func (v *Value) Exp() *Value {
+	out := &Value{Data: math.Exp(v.Data), op: "exp", children: []*Value{v}}
+	out.backward = func() {
+		v.Grad += out.Data * out.Grad
+	}
+	return out
+}
+
+// Log is the natural logarithm. As with math.Log, non-positive inputs give
+// NaN or -Inf rather than an error.
+func (v *Value) Log() *Value {
+	out := &Value{Data: math.Log(v.Data), op: "log", children: []*Value{v}}
+	out.backward = func() {
+		v.Grad += out.Grad / v.Data
+	}
+	return out
+}
+
+func (v *Value) Tanh() *Value {
+	out := &Value{Data: math.Tanh(v.Data), op: "tanh", children: []*Value{v}}
+	out.backward = func() {
+		v.Grad += (1 - out.Data*out.Data) * out.Grad
+	}
+	return out
+}
+
+// ReLU matches network.ReLU, taking the gradient at 0 as 0.
+func (v *Value) ReLU() *Value {
+	out := &Value{Data: max(0, v.Data), op: "relu", children: []*Value{v}}
+	out.backward = func() {
+		if v.Data > 0 {
+			v.Grad += out.Grad
+		}
+	}
+	return out
+}
+
+func (v *Value) Neg() *Value {
+	return v.Mul(NewValue(-1))
+}
+
+func (v *Value) Sub(o *Value) *Value {
+	return v.Add(o.Neg())
+}
+
+func (v *Value) Div(o *Value) *Value {
+	return v.Mul(o.Pow(-1))
+}
+
+// Backward sets the gradient of v to 1 and propagates it to every node of the
+// graph in reverse topological order. Gradients of interior nodes are reset
+// first, so calling Backward again adds the same gradient to the leaves once
+// more.
+func (v *Value) Backward() {
+	order := make([]*Value, 0)
+	visited := make(map[*Value]bool)
+
+	var build func(n *Value)
+	build = func(n *Value) {
+		if visited[n] {
+			return
+		}
+		visited[n] = true
+		for _, child := range n.children {
+			build(child)
+		}
+		order = append(order, n)
+	}
+	build(v)
+
+	for _, n := range order {
+		if len(n.children) > 0 {
+			n.Grad = 0
+		}
+	}
+
+	v.Grad = 1
+	for i := len(order) - 1; i >= 0; i-- {
+		if order[i].backward != nil {
+			order[i].backward()
+		}
+	}
+}
+
+// Sum adds vs together. It returns a zero Value for an empty slice.
+func Sum(vs []*Value) *Value {
+	total := NewValue(0)
+	for _, v := range vs {
+		total = total.Add(v)
+	}
+	return total
+}
+
+// ZeroGrad resets the gradient of each Value.
+func ZeroGrad(vs []*Value) {
+	for _, v := range vs {
+		v.Grad = 0
+	}
+}
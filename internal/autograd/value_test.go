@@ -0,0 +1,150 @@
+package autograd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/obarker94/ml-doe/internal/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueOps(t *testing.T) {
+	t.Run("gradients of each op match their derivatives", func(t *testing.T) {
+		// Arrange
+		cases := map[string]struct {
+			x        float64
+			f        func(*Value) *Value
+			expected float64
+		}{
+			"add":  {1.5, func(v *Value) *Value { return v.Add(NewValue(2)) }, 1},
+			"mul":  {1.5, func(v *Value) *Value { return v.Mul(NewValue(3)) }, 3},
+			"pow":  {1.5, func(v *Value) *Value { return v.Pow(3) }, 3 * 1.5 * 1.5},
+			"exp":  {1.5, func(v *Value) *Value { return v.Exp() }, math.Exp(1.5)},
+			"log":  {1.5, func(v *Value) *Value { return v.Log() }, 1 / 1.5},
+			"tanh": {0.5, func(v *Value) *Value { return v.Tanh() }, 1 - math.Tanh(0.5)*math.Tanh(0.5)},
+			"relu": {-0.5, func(v *Value) *Value { return v.ReLU() }, 0},
+			"sub":  {1.5, func(v *Value) *Value { return NewValue(4).Sub(v) }, -1},
+			"div":  {2.0, func(v *Value) *Value { return NewValue(1).Div(v) }, -0.25},
+		}
+
+		for name, tc := range cases {
+			// Act
+			x := NewValue(tc.x)
+			tc.f(x).Backward()
+
+			// Assert
+			assert.InDelta(t, tc.expected, x.Grad, 1e-12, name)
+		}
+	})
+
+	t.Run("a node used twice accumulates both paths", func(t *testing.T) {
+		// Arrange
+		a := NewValue(3)
+
+		// Act
+		// y = a*a + a, dy/da = 2a + 1
+		y := a.Mul(a).Add(a)
+		y.Backward()
+
+		// Assert
+		assert.InDelta(t, 12.0, y.Data, 1e-12)
+		assert.InDelta(t, 7.0, a.Grad, 1e-12)
+	})
+
+	t.Run("calling Backward twice doubles the leaf gradients", func(t *testing.T) {
+		// Arrange: (a+1)·b with a = 2, b = 3.
+		a, b := NewValue(2), NewValue(3)
+		out := a.Add(NewValue(1)).Mul(b)
+
+		// Act
+		out.Backward()
+		first := a.Grad
+		out.Backward()
+
+		// Assert
+		assert.Equal(t, 3.0, first)
+		assert.Equal(t, 6.0, a.Grad)
+		assert.Equal(t, 6.0, b.Grad)
+	})
+
+	t.Run("ZeroGrad resets leaves between steps", func(t *testing.T) {
+		// Arrange
+		a := NewValue(2)
+		a.Mul(NewValue(5)).Backward()
+
+		// Act
+		ZeroGrad([]*Value{a})
+
+		// Assert
+		assert.Equal(t, 0.0, a.Grad)
+	})
+}
+
+func TestValueCrossCheck(t *testing.T) {
+	t.Run("matches LinearLayer Backward", func(t *testing.T) {
+		// Arrange
+		W := [][]float64{{0.3, -1.2}, {0.8, 0.5}, {-0.4, 0.9}}
+		B := []float64{0.1, -0.2, 0.3}
+		x := []float64{1.5, -0.7}
+		dy := []float64{0.5, -1.0, 2.0}
+
+		ll := &network.LinearLayer{In: 2, Out: 3, W: W, B: B}
+		_, err := ll.Forward(x)
+		assert.NoError(t, err)
+		dx, err := ll.Backward(dy)
+		assert.NoError(t, err)
+
+		wv := make([][]*Value, len(W))
+		for o := range W {
+			wv[o] = Values(W[o])
+		}
+		bv := Values(B)
+		xv := Values(x)
+
+		// Act
+		terms := make([]*Value, 0)
+		for o := range W {
+			y := bv[o]
+			for i := range x {
+				y = y.Add(wv[o][i].Mul(xv[i]))
+			}
+			terms = append(terms, y.Mul(NewValue(dy[o])))
+		}
+		Sum(terms).Backward()
+
+		// Assert
+		for o := range W {
+			for i := range x {
+				assert.InDelta(t, ll.GradW[o][i], wv[o][i].Grad, 1e-12)
+			}
+			assert.InDelta(t, ll.GradB[o], bv[o].Grad, 1e-12)
+		}
+		for i := range x {
+			assert.InDelta(t, dx[i], xv[i].Grad, 1e-12)
+		}
+	})
+
+	t.Run("matches BatchCrossEntropy gradient through softmax", func(t *testing.T) {
+		// Arrange
+		z := []float64{1.2, -0.3, 0.6, 0.1}
+		target := 2
+		res, err := network.BatchCrossEntropy([][]float64{z}, []int{target}, network.CrossEntropyOptions{})
+		assert.NoError(t, err)
+
+		zv := Values(z)
+
+		// Act
+		exps := make([]*Value, len(zv))
+		for i, v := range zv {
+			exps[i] = v.Exp()
+		}
+		loss := exps[target].Div(Sum(exps)).Log().Neg()
+		loss.Backward()
+
+		// Assert
+		assert.InDelta(t, res.Loss, loss.Data, 1e-12)
+		for i := range z {
+			assert.InDelta(t, res.Grad[0][i], zv[i].Grad, 1e-12)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package doe
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/obarker94/ml-doe/internal/network"
+)
+
+// TrainingConfig is the MLP training setup of a single run.
+type TrainingConfig struct {
+	LearningRate float64
+	HiddenSize   int
+	Activation   string
+	Init         string
+}
+
+// DefaultTrainingConfig is used for every hyperparameter a design does not
+// vary.
+func DefaultTrainingConfig() TrainingConfig {
+	return TrainingConfig{LearningRate: 0.01, HiddenSize: 16, Activation: "relu", Init: "he"}
+}
+
+// set parses and validates level as the value of the named factor.
+func (c *TrainingConfig) set(name, level string) error {
+	switch name {
+	case LearningRate:
+		lr, err := strconv.ParseFloat(level, 64)
+		if err != nil || !(lr > 0) || math.IsInf(lr, 0) {
+			return fmt.Errorf("invalid learning rate %q", level)
+		}
+		c.LearningRate = lr
+	case HiddenSize:
+		size, err := strconv.Atoi(level)
+		if err != nil || size <= 0 {
+			return fmt.Errorf("invalid hidden size %q", level)
+		}
+		c.HiddenSize = size
+	case Activation:
+		c.Activation = level
+		if _, err := c.Nonlinearity(); err != nil {
+			return err
+		}
+	case Init:
+		c.Init = level
+		if _, err := c.Initializer(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown factor %s", name)
+	}
+
+	return nil
+}
+
+func (c TrainingConfig) Nonlinearity() (network.Nonlinearity, error) {
+	switch c.Activation {
+	case "relu":
+		return network.ReLU{}, nil
+	case "tanh":
+		return network.Tanh{}, nil
+	case "sigmoid":
+		return network.Sigmoid{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown activation %q", c.Activation)
+}
+
+func (c TrainingConfig) Initializer() (network.Initializer, error) {
+	switch c.Init {
+	case "xavier":
+		return network.XavierUniform, nil
+	case "he":
+		return network.HeNormal, nil
+	}
+
+	return nil, fmt.Errorf("unknown init scheme %q", c.Init)
+}
+
+// NewMLP builds an MLP with in inputs and out outputs for this configuration,
+// initialised deterministically from seed.
+func (c TrainingConfig) NewMLP(in, out int, seed uint64) (*network.MLP, error) {
+	nl, err := c.Nonlinearity()
+	if err != nil {
+		return nil, err
+	}
+	init, err := c.Initializer()
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewPCG(seed, seed))
+	hidden, err := network.NewLinearLayer(in, c.HiddenSize, true, init, rng)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create hidden layer"), err)
+	}
+	output, err := network.NewLinearLayer(c.HiddenSize, out, true, init, rng)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create output layer"), err)
+	}
+
+	return &network.MLP{
+		Hidden: network.Block{LinearLayer: *hidden, Nonlinearity: nl},
+		Out:    *output,
+	}, nil
+}
+
+// Optimizer returns plain SGD at the configured learning rate.
+func (c TrainingConfig) Optimizer() network.Optimizer {
+	return &network.SGD{LearningRate: c.LearningRate}
+}
@@ -0,0 +1,52 @@
+package doe
+
+import (
+	"testing"
+
+	"github.com/obarker94/ml-doe/internal/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrainingConfig(t *testing.T) {
+	t.Run("builds an MLP with the configured width and activation", func(t *testing.T) {
+		// Arrange
+		cfg := TrainingConfig{LearningRate: 0.05, HiddenSize: 6, Activation: "tanh", Init: "xavier"}
+
+		// Act
+		mlp, err := cfg.NewMLP(3, 2, 1)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mlp.Validate())
+		assert.Equal(t, 6, mlp.Hidden.LinearLayer.Out)
+		assert.Equal(t, network.Tanh{}, mlp.Hidden.Nonlinearity)
+		out, err := mlp.Forward([]float64{1, -1, 0.5})
+		assert.NoError(t, err)
+		assert.Len(t, out, 2)
+		assert.Equal(t, &network.SGD{LearningRate: 0.05}, cfg.Optimizer())
+	})
+
+	t.Run("is deterministic for a seed", func(t *testing.T) {
+		// Arrange
+		cfg := DefaultTrainingConfig()
+
+		// Act
+		m1, err1 := cfg.NewMLP(2, 2, 7)
+		m2, err2 := cfg.NewMLP(2, 2, 7)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, m1.Hidden.LinearLayer.W, m2.Hidden.LinearLayer.W)
+	})
+
+	t.Run("rejects unknown activations and init schemes", func(t *testing.T) {
+		// Act
+		_, errActivation := TrainingConfig{HiddenSize: 2, Activation: "gelu", Init: "he"}.NewMLP(2, 2, 1)
+		_, errInit := TrainingConfig{HiddenSize: 2, Activation: "relu", Init: "orthogonal"}.NewMLP(2, 2, 1)
+
+		// Assert
+		assert.Error(t, errActivation)
+		assert.Error(t, errInit)
+	})
+}
@@ -0,0 +1,146 @@
+package doe
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Run is one row of a design. Levels[i] indexes Factors[i].Levels.
+type Run struct {
+	// ID is the 1-based position of the run in standard order.
+	ID int
+	// Order is the 1-based position in which the run should be executed.
+	Order  int
+	Levels []int
+}
+
+// Design is a run matrix over Factors. For fractional designs Generators,
+// DefiningRelation and Resolution describe the aliasing; a full factorial
+// has no aliasing and a Resolution of 0.
+type Design struct {
+	Factors          []Factor
+	Runs             []Run
+	Generators       []string
+	DefiningRelation []string
+	Resolution       int
+}
+
+// FullFactorial crosses every level of every factor. Runs are in standard
+// order, with the first factor changing fastest.
+func FullFactorial(factors ...Factor) (*Design, error) {
+	if err := validateFactors(factors); err != nil {
+		return nil, err
+	}
+
+	total := 1
+	for _, f := range factors {
+		total *= len(f.Levels)
+	}
+
+	runs := make([]Run, total)
+	for id := range runs {
+		levels := make([]int, len(factors))
+		rest := id
+		for i, f := range factors {
+			levels[i] = rest % len(f.Levels)
+			rest /= len(f.Levels)
+		}
+		runs[id] = Run{ID: id + 1, Order: id + 1, Levels: levels}
+	}
+
+	return &Design{Factors: factors, Runs: runs}, nil
+}
+
+// Randomize shuffles the execution order of the runs with a seeded RNG, so
+// drift over time (e.g. a warming machine) is not confounded with a factor.
+// Runs are reordered and their Order renumbered; IDs keep the standard order.
+func (d *Design) Randomize(seed uint64) {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	rng.Shuffle(len(d.Runs), func(i, j int) { d.Runs[i], d.Runs[j] = d.Runs[j], d.Runs[i] })
+
+	for i := range d.Runs {
+		d.Runs[i].Order = i + 1
+	}
+}
+
+// Config maps run to an MLP training configuration, starting from
+// DefaultTrainingConfig for hyperparameters the design does not vary.
+func (d *Design) Config(run Run) (TrainingConfig, error) {
+	if len(run.Levels) != len(d.Factors) {
+		return TrainingConfig{}, fmt.Errorf("dimension mismatch: run has %d levels for %d factors", len(run.Levels), len(d.Factors))
+	}
+
+	cfg := DefaultTrainingConfig()
+	for i, f := range d.Factors {
+		if run.Levels[i] < 0 || run.Levels[i] >= len(f.Levels) {
+			return TrainingConfig{}, fmt.Errorf("level %d out of range for factor %s", run.Levels[i], f.Name)
+		}
+		if err := cfg.set(f.Name, f.Levels[run.Levels[i]]); err != nil {
+			return TrainingConfig{}, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// Describe summarises the design type and, for fractional designs, its
+// resolution and aliasing.
+func (d *Design) Describe() string {
+	if d.Resolution == 0 {
+		return fmt.Sprintf("full factorial, %d factors, %d runs", len(d.Factors), len(d.Runs))
+	}
+
+	k, p := len(d.Factors), len(d.Generators)
+	return fmt.Sprintf("2^(%d-%d) fractional factorial, resolution %s, %d runs, generators %s, defining relation I=%s",
+		k, p, ResolutionName(d.Resolution), len(d.Runs), strings.Join(d.Generators, " "), strings.Join(d.DefiningRelation, "="))
+}
+
+// String renders Describe followed by the run matrix in execution order.
+func (d *Design) String() string {
+	var sb strings.Builder
+	sb.WriteString(d.Describe())
+	sb.WriteString("\n")
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprint(w, "order\trun")
+	for _, f := range d.Factors {
+		fmt.Fprintf(w, "\t%s", f.Name)
+	}
+	fmt.Fprintln(w)
+
+	for _, run := range d.Runs {
+		fmt.Fprintf(w, "%d\t%d", run.Order, run.ID)
+		for i, level := range run.Levels {
+			fmt.Fprintf(w, "\t%s", d.Factors[i].Levels[level])
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+
+	return sb.String()
+}
+
+// ResolutionName is the conventional roman numeral of a resolution.
+func ResolutionName(resolution int) string {
+	numerals := []struct {
+		value  int
+		symbol string
+	}{{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"}}
+
+	if resolution <= 0 {
+		return strconv.Itoa(resolution)
+	}
+
+	var sb strings.Builder
+	for _, n := range numerals {
+		for resolution >= n.value {
+			sb.WriteString(n.symbol)
+			resolution -= n.value
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,96 @@
+package doe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFullFactorial(t *testing.T) {
+	factors := []Factor{LearningRateFactor(0.1, 0.01), HiddenSizeFactor(8, 16, 32), ActivationFactor("relu", "tanh")}
+
+	t.Run("crosses every level in standard order", func(t *testing.T) {
+		// Act
+		d, err := FullFactorial(factors...)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, d.Runs, 12)
+		assert.Equal(t, Run{ID: 1, Order: 1, Levels: []int{0, 0, 0}}, d.Runs[0])
+		assert.Equal(t, []int{1, 0, 0}, d.Runs[1].Levels)
+		assert.Equal(t, []int{0, 1, 0}, d.Runs[2].Levels)
+		assert.Equal(t, []int{1, 2, 1}, d.Runs[11].Levels)
+		assert.Equal(t, 0, d.Resolution)
+		assert.Equal(t, "full factorial, 3 factors, 12 runs", d.Describe())
+	})
+
+	t.Run("randomises run order reproducibly", func(t *testing.T) {
+		// Arrange
+		d1, _ := FullFactorial(factors...)
+		d2, _ := FullFactorial(factors...)
+
+		// Act
+		d1.Randomize(42)
+		d2.Randomize(42)
+
+		// Assert
+		assert.Equal(t, d1.Runs, d2.Runs)
+		ids := make(map[int]bool)
+		inStandardOrder := true
+		for i, run := range d1.Runs {
+			assert.Equal(t, i+1, run.Order)
+			ids[run.ID] = true
+			inStandardOrder = inStandardOrder && run.ID == i+1
+		}
+		assert.Len(t, ids, 12)
+		assert.False(t, inStandardOrder)
+	})
+
+	t.Run("maps runs to training configurations", func(t *testing.T) {
+		// Arrange
+		d, err := FullFactorial(factors...)
+		assert.NoError(t, err)
+
+		// Act
+		cfg, err := d.Config(d.Runs[11])
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, TrainingConfig{LearningRate: 0.01, HiddenSize: 32, Activation: "tanh", Init: "he"}, cfg)
+	})
+
+	t.Run("rejects factors it cannot map", func(t *testing.T) {
+		// Arrange
+		d, err := FullFactorial(Factor{Name: "momentum", Levels: []string{"0", "0.9"}})
+		assert.NoError(t, err)
+
+		// Act
+		_, err = d.Config(d.Runs[0])
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("prints the run matrix", func(t *testing.T) {
+		// Arrange
+		d, err := FullFactorial(factors[0], factors[2])
+		assert.NoError(t, err)
+
+		// Act
+		out := d.String()
+
+		// Assert
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		assert.Len(t, lines, 6)
+		assert.Equal(t, "order  run  learning_rate  activation", lines[1])
+		assert.Equal(t, "4      4    0.01           tanh", lines[5])
+	})
+}
+
+func TestResolutionName(t *testing.T) {
+	assert.Equal(t, "III", ResolutionName(3))
+	assert.Equal(t, "IV", ResolutionName(4))
+	assert.Equal(t, "V", ResolutionName(5))
+	assert.Equal(t, "VIII", ResolutionName(8))
+}
@@ -0,0 +1,114 @@
+// Package doe generates designed experiments over training hyperparameters.
+package doe
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Factor is a hyperparameter and the levels it is tested at. Levels are kept
+// as labels so designs print cleanly; TrainingConfig parses the factors it
+// knows about.
+type Factor struct {
+	Name   string
+	Levels []string
+}
+
+// Factor names understood by TrainingConfig.
+const (
+	LearningRate = "learning_rate"
+	HiddenSize   = "hidden_size"
+	Activation   = "activation"
+	Init         = "init"
+)
+
+func LearningRateFactor(levels ...float64) Factor {
+	labels := make([]string, len(levels))
+	for i, lr := range levels {
+		labels[i] = strconv.FormatFloat(lr, 'g', -1, 64)
+	}
+
+	return Factor{Name: LearningRate, Levels: labels}
+}
+
+func HiddenSizeFactor(levels ...int) Factor {
+	labels := make([]string, len(levels))
+	for i, size := range levels {
+		labels[i] = strconv.Itoa(size)
+	}
+
+	return Factor{Name: HiddenSize, Levels: labels}
+}
+
+// ActivationFactor takes activation names: "relu", "tanh" or "sigmoid".
+func ActivationFactor(levels ...string) Factor {
+	return Factor{Name: Activation, Levels: levels}
+}
+
+// InitFactor takes initialiser names: "xavier" or "he".
+func InitFactor(levels ...string) Factor {
+	return Factor{Name: Init, Levels: levels}
+}
+
+func (f Factor) Validate() error {
+	if f.Name == "" {
+		return fmt.Errorf("factor must have a name")
+	}
+	if len(f.Levels) < 2 {
+		return fmt.Errorf("factor %s must have at least 2 levels, got %d", f.Name, len(f.Levels))
+	}
+
+	seen := make(map[string]bool, len(f.Levels))
+	for _, level := range f.Levels {
+		if seen[level] {
+			return fmt.Errorf("factor %s has duplicate level %q", f.Name, level)
+		}
+		seen[level] = true
+	}
+
+	return nil
+}
+
+func validateFactors(factors []Factor) error {
+	if len(factors) == 0 {
+		return fmt.Errorf("design must have factors")
+	}
+
+	seen := make(map[string]bool, len(factors))
+	for _, f := range factors {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+		if seen[f.Name] {
+			return fmt.Errorf("duplicate factor %s", f.Name)
+		}
+		seen[f.Name] = true
+
+		if err := validateLevels(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateLevels checks that every level of a factor TrainingConfig
+// understands can be applied, so a misspelt level fails when the design is
+// built rather than part-way through a study. Other factors are left to the
+// caller.
+func validateLevels(f Factor) error {
+	switch f.Name {
+	case LearningRate, HiddenSize, Activation, Init:
+	default:
+		return nil
+	}
+
+	cfg := DefaultTrainingConfig()
+	for _, level := range f.Levels {
+		if err := cfg.set(f.Name, level); err != nil {
+			return fmt.Errorf("factor %s: %w", f.Name, err)
+		}
+	}
+
+	return nil
+}
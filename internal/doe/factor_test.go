@@ -0,0 +1,53 @@
+package doe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFactor(t *testing.T) {
+	t.Run("helpers label levels by value", func(t *testing.T) {
+		// Act
+		lr := LearningRateFactor(0.1, 0.001)
+		hidden := HiddenSizeFactor(8, 64)
+
+		// Assert
+		assert.Equal(t, Factor{Name: LearningRate, Levels: []string{"0.1", "0.001"}}, lr)
+		assert.Equal(t, Factor{Name: HiddenSize, Levels: []string{"8", "64"}}, hidden)
+	})
+
+	t.Run("rejects factors with fewer than two or duplicate levels", func(t *testing.T) {
+		// Assert
+		assert.Error(t, ActivationFactor("relu").Validate())
+		assert.Error(t, InitFactor("he", "he").Validate())
+		assert.Error(t, Factor{Levels: []string{"a", "b"}}.Validate())
+		assert.NoError(t, InitFactor("he", "xavier").Validate())
+	})
+
+	t.Run("rejects levels TrainingConfig cannot apply when building a design", func(t *testing.T) {
+		// Act
+		_, errActivation := FullFactorial(ActivationFactor("relu", "relu6"))
+		_, errInit := FractionalFactorial([]Factor{
+			LearningRateFactor(0.1, 0.01), HiddenSizeFactor(8, 16), InitFactor("he", "orthogonal"),
+		}, []string{"C=AB"})
+		_, errSize := FullFactorial(Factor{Name: HiddenSize, Levels: []string{"8", "wide"}})
+		_, errNaN := FullFactorial(Factor{Name: LearningRate, Levels: []string{"0.1", "NaN"}})
+		_, errInf := FullFactorial(Factor{Name: LearningRate, Levels: []string{"Inf", "0.1"}})
+
+		// Assert
+		assert.ErrorContains(t, errActivation, "relu6")
+		assert.ErrorContains(t, errInit, "orthogonal")
+		assert.Error(t, errSize)
+		assert.ErrorContains(t, errNaN, "NaN")
+		assert.ErrorContains(t, errInf, "Inf")
+	})
+
+	t.Run("rejects duplicate factors in a design", func(t *testing.T) {
+		// Act
+		_, err := FullFactorial(InitFactor("he", "xavier"), InitFactor("xavier", "he"))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
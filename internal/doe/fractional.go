@@ -0,0 +1,257 @@
+package doe
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+// Factors of a fractional design are named by letter in the order given,
+// skipping I which denotes the identity in defining relations.
+const factorLetters = "ABCDEFGHJKLMNOPQRSTUVWXYZ"
+
+// word is a product of factors, one bit per factor. Multiplying words is XOR
+// as every two-level factor squares to the identity.
+type word uint32
+
+func (w word) String() string {
+	var sb strings.Builder
+	for i := range factorLetters {
+		if w&(1<<i) != 0 {
+			sb.WriteByte(factorLetters[i])
+		}
+	}
+
+	return sb.String()
+}
+
+// FractionalFactorial builds a 2^(k-p) design over k two-level factors. The
+// first k-p factors form a full factorial and each of the remaining p is set
+// by a generator such as "D=ABC", the coded (-1/+1) product of the named base
+// factors.
+func FractionalFactorial(factors []Factor, generators []string) (*Design, error) {
+	if err := validateTwoLevel(factors); err != nil {
+		return nil, err
+	}
+
+	k, p := len(factors), len(generators)
+	if p < 1 || p >= k {
+		return nil, fmt.Errorf("need between 1 and %d generators for %d factors, got %d", k-1, k, p)
+	}
+
+	words := make([]word, p)
+	for g, generator := range generators {
+		added, base, err := parseGenerator(generator, k-p)
+		if err != nil {
+			return nil, err
+		}
+		if added != k-p+g {
+			return nil, fmt.Errorf("generator %q must define factor %c", generator, factorLetters[k-p+g])
+		}
+		words[g] = base
+	}
+
+	return fractionalDesign(factors, words), nil
+}
+
+// BestFractionalFactorial builds the 2^(k-p) design with the highest
+// resolution, preferring fewer shortest words in the defining relation among
+// designs of equal resolution. The search is exhaustive, so it is meant for
+// the handful of factors a hyperparameter study has.
+func BestFractionalFactorial(factors []Factor, p int) (*Design, error) {
+	if err := validateTwoLevel(factors); err != nil {
+		return nil, err
+	}
+
+	k := len(factors)
+	if p < 1 || p >= k {
+		return nil, fmt.Errorf("p must be between 1 and %d for %d factors, got %d", k-1, k, p)
+	}
+
+	// Candidate generators are interactions of at least two base factors.
+	var candidates []word
+	for w := word(1); w < 1<<(k-p); w++ {
+		if bits.OnesCount32(uint32(w)) >= 2 {
+			candidates = append(candidates, w)
+		}
+	}
+	if len(candidates) < p {
+		return nil, fmt.Errorf("too few base factors for %d generators; reduce p", p)
+	}
+
+	var best []word
+	bestResolution, bestCount := 0, 0
+	chosen := make([]word, 0, p)
+
+	var search func(start int)
+	search = func(start int) {
+		if len(chosen) == p {
+			relation := definingRelation(chosen, k-p)
+			resolution := bits.OnesCount32(uint32(relation[0]))
+			count := 0
+			for _, w := range relation {
+				if bits.OnesCount32(uint32(w)) == resolution {
+					count++
+				}
+			}
+			if resolution > bestResolution || (resolution == bestResolution && count < bestCount) {
+				best = append([]word(nil), chosen...)
+				bestResolution, bestCount = resolution, count
+			}
+			return
+		}
+		for i := start; i < len(candidates); i++ {
+			chosen = append(chosen, candidates[i])
+			search(i + 1)
+			chosen = chosen[:len(chosen)-1]
+		}
+	}
+	search(0)
+
+	return fractionalDesign(factors, best), nil
+}
+
+// fractionalDesign builds the design for base-factor generator words, one per
+// added factor.
+func fractionalDesign(factors []Factor, generators []word) *Design {
+	k, p := len(factors), len(generators)
+	base := k - p
+
+	runs := make([]Run, 1<<base)
+	for id := range runs {
+		levels := make([]int, k)
+		for i := range base {
+			levels[i] = (id >> i) & 1
+		}
+		for g, gen := range generators {
+			// The coded product is +1 when an even number of factors are low.
+			low := bits.OnesCount32(uint32(gen) &^ uint32(id))
+			if low%2 == 0 {
+				levels[base+g] = 1
+			}
+		}
+		runs[id] = Run{ID: id + 1, Order: id + 1, Levels: levels}
+	}
+
+	labels := make([]string, p)
+	for g, gen := range generators {
+		labels[g] = fmt.Sprintf("%c=%s", factorLetters[base+g], gen)
+	}
+
+	relation := definingRelation(generators, base)
+	relationLabels := make([]string, len(relation))
+	for i, w := range relation {
+		relationLabels[i] = w.String()
+	}
+
+	return &Design{
+		Factors:          factors,
+		Runs:             runs,
+		Generators:       labels,
+		DefiningRelation: relationLabels,
+		Resolution:       bits.OnesCount32(uint32(relation[0])),
+	}
+}
+
+// definingRelation returns every non-identity product of the defining words
+// of generators, shortest first.
+func definingRelation(generators []word, base int) []word {
+	defining := make([]word, len(generators))
+	for g, gen := range generators {
+		defining[g] = gen | 1<<(base+g)
+	}
+
+	relation := make([]word, 0, 1<<len(defining)-1)
+	for subset := 1; subset < 1<<len(defining); subset++ {
+		var w word
+		for g, d := range defining {
+			if subset&(1<<g) != 0 {
+				w ^= d
+			}
+		}
+		relation = append(relation, w)
+	}
+
+	sort.Slice(relation, func(i, j int) bool {
+		li, lj := bits.OnesCount32(uint32(relation[i])), bits.OnesCount32(uint32(relation[j]))
+		if li != lj {
+			return li < lj
+		}
+		return relation[i].String() < relation[j].String()
+	})
+
+	return relation
+}
+
+// Aliases returns the effects confounded with the main effect of factor i in
+// a fractional design, e.g. ["BD", "ACE"].
+func (d *Design) Aliases(i int) ([]string, error) {
+	if i < 0 || i >= len(d.Factors) {
+		return nil, fmt.Errorf("factor %d out of range for %d factors", i, len(d.Factors))
+	}
+
+	aliases := make([]string, 0, len(d.DefiningRelation))
+	for _, label := range d.DefiningRelation {
+		w, err := parseWord(label, len(d.Factors))
+		if err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, (w ^ 1<<i).String())
+	}
+
+	return aliases, nil
+}
+
+// parseGenerator parses "D=ABC" into the index of D and the word ABC, which
+// may only use the first base factors.
+func parseGenerator(generator string, base int) (int, word, error) {
+	lhs, rhs, ok := strings.Cut(strings.ReplaceAll(generator, " ", ""), "=")
+	if !ok || len(lhs) != 1 || rhs == "" {
+		return 0, 0, fmt.Errorf("generator %q must look like D=ABC", generator)
+	}
+
+	added := strings.IndexByte(factorLetters, lhs[0])
+	if added < 0 {
+		return 0, 0, fmt.Errorf("generator %q defines unknown factor %s", generator, lhs)
+	}
+
+	w, err := parseWord(rhs, base)
+	if err != nil {
+		return 0, 0, fmt.Errorf("generator %q: %w", generator, err)
+	}
+	if bits.OnesCount32(uint32(w)) != len(rhs) {
+		return 0, 0, fmt.Errorf("generator %q repeats a factor", generator)
+	}
+
+	return added, w, nil
+}
+
+func parseWord(s string, factors int) (word, error) {
+	var w word
+	for _, r := range s {
+		i := strings.IndexRune(factorLetters, r)
+		if i < 0 || i >= factors {
+			return 0, fmt.Errorf("unknown factor %c", r)
+		}
+		w |= 1 << i
+	}
+
+	return w, nil
+}
+
+func validateTwoLevel(factors []Factor) error {
+	if err := validateFactors(factors); err != nil {
+		return err
+	}
+	if len(factors) > len(factorLetters) {
+		return fmt.Errorf("at most %d factors are supported, got %d", len(factorLetters), len(factors))
+	}
+	for _, f := range factors {
+		if len(f.Levels) != 2 {
+			return fmt.Errorf("fractional designs need two-level factors; %s has %d levels", f.Name, len(f.Levels))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package doe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func twoLevelFactors(k int) []Factor {
+	all := []Factor{
+		LearningRateFactor(0.1, 0.01),
+		HiddenSizeFactor(8, 32),
+		ActivationFactor("relu", "tanh"),
+		InitFactor("xavier", "he"),
+	}
+	for i := len(all); i < k; i++ {
+		all = append(all, Factor{Name: string(rune('a' + i)), Levels: []string{"lo", "hi"}})
+	}
+	return all[:k]
+}
+
+// coded maps a level index to -1/+1.
+func coded(level int) int {
+	return 2*level - 1
+}
+
+func TestFractionalFactorial(t *testing.T) {
+	t.Run("builds a half fraction from a generator", func(t *testing.T) {
+		// Act
+		d, err := FractionalFactorial(twoLevelFactors(4), []string{"D=ABC"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, d.Runs, 8)
+		assert.Equal(t, 4, d.Resolution)
+		assert.Equal(t, []string{"ABCD"}, d.DefiningRelation)
+		for _, run := range d.Runs {
+			l := run.Levels
+			assert.Equal(t, coded(l[0])*coded(l[1])*coded(l[2]), coded(l[3]))
+		}
+		assert.Equal(t, "2^(4-1) fractional factorial, resolution IV, 8 runs, generators D=ABC, defining relation I=ABCD", d.Describe())
+	})
+
+	t.Run("columns are balanced and orthogonal", func(t *testing.T) {
+		// Act
+		d, err := BestFractionalFactorial(twoLevelFactors(7), 4)
+		assert.NoError(t, err)
+
+		// Assert
+		for i := range d.Factors {
+			sum := 0
+			for _, run := range d.Runs {
+				sum += coded(run.Levels[i])
+			}
+			assert.Equal(t, 0, sum, "factor %d unbalanced", i)
+
+			for j := i + 1; j < len(d.Factors); j++ {
+				dot := 0
+				for _, run := range d.Runs {
+					dot += coded(run.Levels[i]) * coded(run.Levels[j])
+				}
+				assert.Equal(t, 0, dot, "factors %d and %d not orthogonal", i, j)
+			}
+		}
+	})
+
+	t.Run("finds the maximum resolution", func(t *testing.T) {
+		for _, tc := range []struct {
+			k, p, runs, resolution int
+		}{
+			{4, 1, 8, 4},
+			{5, 1, 16, 5},
+			{5, 2, 8, 3},
+			{6, 2, 16, 4},
+			{7, 4, 8, 3},
+		} {
+			// Act
+			d, err := BestFractionalFactorial(twoLevelFactors(tc.k), tc.p)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Len(t, d.Runs, tc.runs)
+			assert.Equal(t, tc.resolution, d.Resolution, "2^(%d-%d)", tc.k, tc.p)
+			assert.Len(t, d.DefiningRelation, 1<<tc.p-1)
+		}
+	})
+
+	t.Run("reports main-effect aliases", func(t *testing.T) {
+		// Arrange
+		d, err := FractionalFactorial(twoLevelFactors(5), []string{"D=AB", "E=AC"})
+		assert.NoError(t, err)
+
+		// Act
+		aliases, err := d.Aliases(0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ABD", "ACE", "BCDE"}, d.DefiningRelation)
+		assert.Equal(t, []string{"BD", "CE", "ABCDE"}, aliases)
+		assert.Equal(t, 3, d.Resolution)
+	})
+
+	t.Run("runs map to MLP training configurations", func(t *testing.T) {
+		// Arrange
+		d, err := FractionalFactorial(twoLevelFactors(4), []string{"D=ABC"})
+		assert.NoError(t, err)
+		d.Randomize(1)
+
+		for _, run := range d.Runs {
+			// Act
+			cfg, err := d.Config(run)
+			assert.NoError(t, err)
+			mlp, mlpErr := cfg.NewMLP(2, 3, uint64(run.ID))
+
+			// Assert
+			assert.NoError(t, mlpErr)
+			assert.Equal(t, cfg.HiddenSize, mlp.Hidden.LinearLayer.Out)
+		}
+	})
+
+	t.Run("rejects invalid designs", func(t *testing.T) {
+		// Arrange
+		three := append(twoLevelFactors(3), HiddenSizeFactor(1, 2, 3))
+		three[1] = Factor{Name: "x", Levels: []string{"a", "b"}}
+
+		// Act
+		_, errLevels := FractionalFactorial(three, []string{"D=ABC"})
+		_, errLHS := FractionalFactorial(twoLevelFactors(4), []string{"C=AB"})
+		_, errRHS := FractionalFactorial(twoLevelFactors(4), []string{"D=ABD"})
+		_, errSyntax := FractionalFactorial(twoLevelFactors(4), []string{"ABC"})
+		_, errP := BestFractionalFactorial(twoLevelFactors(3), 3)
+
+		// Assert
+		assert.Error(t, errLevels)
+		assert.Error(t, errLHS)
+		assert.Error(t, errRHS)
+		assert.Error(t, errSyntax)
+		assert.Error(t, errP)
+	})
+}
@@ -0,0 +1,236 @@
+package eval
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/obarker94/ml-doe/internal/network"
+)
+
+// ReliabilityBin groups predictions whose confidence (max softmax
+// probability) falls in [Lower, Upper). The last bin includes 1.
+type ReliabilityBin struct {
+	Lower      float64
+	Upper      float64
+	Count      int
+	Confidence float64 // mean confidence of the bin, 0 when empty
+	Accuracy   float64 // fraction of the bin predicted correctly, 0 when empty
+}
+
+// Gap is |Accuracy - Confidence|.
+func (b ReliabilityBin) Gap() float64 {
+	return math.Abs(b.Accuracy - b.Confidence)
+}
+
+// CalibrationReport is the data behind a reliability diagram. ECE is the
+// count-weighted mean gap over bins and MCE the largest gap of a non-empty
+// bin.
+type CalibrationReport struct {
+	Samples int
+	Bins    []ReliabilityBin
+	ECE     float64
+	MCE     float64
+}
+
+// Calibration bins the confidence of each row of logits into bins
+// equal-width bins and compares it with the accuracy of the ArgMax prediction.
+func Calibration(logits [][]float64, targets []int, bins int) (CalibrationReport, error) {
+	if bins <= 0 {
+		return CalibrationReport{}, fmt.Errorf("bins must be positive, got %d", bins)
+	}
+	if err := validateLogits(logits, targets); err != nil {
+		return CalibrationReport{}, err
+	}
+
+	report := CalibrationReport{Samples: len(logits), Bins: make([]ReliabilityBin, bins)}
+	for b := range report.Bins {
+		report.Bins[b].Lower = float64(b) / float64(bins)
+		report.Bins[b].Upper = float64(b+1) / float64(bins)
+	}
+
+	for idx, z := range logits {
+		_, stats, err := network.SoftmaxWithStats(z)
+		if err != nil {
+			return CalibrationReport{}, errors.Join(fmt.Errorf("unable to compute softmax of sample %d", idx), err)
+		}
+		predicted, err := network.ArgMax(z)
+		if err != nil {
+			return CalibrationReport{}, err
+		}
+
+		b := min(int(stats.MaxProb*float64(bins)), bins-1)
+		report.Bins[b].Count++
+		report.Bins[b].Confidence += stats.MaxProb
+		if predicted == targets[idx] {
+			report.Bins[b].Accuracy++
+		}
+	}
+
+	for b := range report.Bins {
+		bin := &report.Bins[b]
+		if bin.Count == 0 {
+			continue
+		}
+		bin.Confidence /= float64(bin.Count)
+		bin.Accuracy /= float64(bin.Count)
+
+		report.ECE += float64(bin.Count) / float64(report.Samples) * bin.Gap()
+		report.MCE = max(report.MCE, bin.Gap())
+	}
+
+	return report, nil
+}
+
+// WriteCSV writes one row per bin with a header, for plotting a reliability
+// diagram.
+func (r CalibrationReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"lower", "upper", "count", "confidence", "accuracy", "gap"}); err != nil {
+		return err
+	}
+
+	format := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+	for _, b := range r.Bins {
+		row := []string{format(b.Lower), format(b.Upper), strconv.Itoa(b.Count), format(b.Confidence), format(b.Accuracy), format(b.Gap())}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// CollectLogits runs model over every input of data, e.g. to gather the
+// validation logits of an MLP for Calibration and FitTemperature. Like
+// Evaluate it runs models implementing network.TrainingMode in evaluation
+// mode and restores their previous mode on return.
+func CollectLogits(model Model, data Dataset) ([][]float64, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+	defer evaluationMode(model)()
+
+	logits := make([][]float64, len(data.Inputs))
+	for idx, x := range data.Inputs {
+		z, err := model.Forward(x)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("model failed on sample %d", idx), err)
+		}
+		logits[idx] = z
+	}
+
+	return logits, nil
+}
+
+// ScaleTemperature returns logits divided by temperature.
+func ScaleTemperature(logits [][]float64, temperature float64) ([][]float64, error) {
+	if !(temperature > 0) || math.IsInf(temperature, 0) {
+		return nil, fmt.Errorf("temperature must be positive and finite, got %v", temperature)
+	}
+
+	scaled := make([][]float64, len(logits))
+	for idx, z := range logits {
+		scaled[idx] = make([]float64, len(z))
+		for i, v := range z {
+			scaled[idx][i] = v / temperature
+		}
+	}
+
+	return scaled, nil
+}
+
+// MeanNLL is the mean negative log-likelihood of targets under the softmax of
+// logits.
+func MeanNLL(logits [][]float64, targets []int) (float64, error) {
+	if err := validateLogits(logits, targets); err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+	for idx, z := range logits {
+		logProbs, err := network.LogSoftmax(z)
+		if err != nil {
+			return 0, errors.Join(fmt.Errorf("unable to compute log-softmax of sample %d", idx), err)
+		}
+		loss, err := network.NLLLoss(logProbs, targets[idx])
+		if err != nil {
+			return 0, errors.Join(fmt.Errorf("unable to compute loss of sample %d", idx), err)
+		}
+		total += loss
+	}
+
+	return total / float64(len(logits)), nil
+}
+
+// Bounds on the fitted inverse temperature. Perfectly separable data drives
+// the optimum to the upper bound.
+const (
+	minInverseTemperature = 1e-4
+	maxInverseTemperature = 1e4
+)
+
+// FitTemperature returns the temperature T minimising MeanNLL(logits/T),
+// usually on a validation set. The NLL is convex in 1/T with derivative
+// E_p[z] - z_target, so the minimum is found by bisecting that derivative in
+// log space.
+func FitTemperature(logits [][]float64, targets []int) (float64, error) {
+	if err := validateLogits(logits, targets); err != nil {
+		return 0, err
+	}
+
+	slope := func(beta float64) (float64, error) {
+		total := 0.0
+		for idx, z := range logits {
+			scaled := make([]float64, len(z))
+			for i, v := range z {
+				scaled[i] = beta * v
+			}
+			p, _, err := network.SoftmaxWithStats(scaled)
+			if err != nil {
+				return 0, errors.Join(fmt.Errorf("unable to compute softmax of sample %d", idx), err)
+			}
+			for i, v := range z {
+				total += p[i] * v
+			}
+			total -= z[targets[idx]]
+		}
+		return total / float64(len(logits)), nil
+	}
+
+	lo, hi := math.Log(minInverseTemperature), math.Log(maxInverseTemperature)
+	for range 100 {
+		mid := (lo + hi) / 2
+		d, err := slope(math.Exp(mid))
+		if err != nil {
+			return 0, err
+		}
+		if d < 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return 1 / math.Exp((lo+hi)/2), nil
+}
+
+func validateLogits(logits [][]float64, targets []int) error {
+	if len(logits) == 0 {
+		return fmt.Errorf("logits must have samples")
+	}
+	if len(logits) != len(targets) {
+		return fmt.Errorf("dimension mismatch: %d logit rows and %d targets", len(logits), len(targets))
+	}
+	for idx, z := range logits {
+		if targets[idx] < 0 || targets[idx] >= len(z) {
+			return fmt.Errorf("target %d of sample %d out of range for %d classes", targets[idx], idx, len(z))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package eval
+
+import (
+	"bytes"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/obarker94/ml-doe/internal/network"
+	"github.com/stretchr/testify/assert"
+)
+
+// logitsFor returns two-class logits whose softmax gives class 0 probability p.
+func logitsFor(p float64) []float64 {
+	return []float64{math.Log(p / (1 - p)), 0}
+}
+
+func TestCalibration(t *testing.T) {
+	t.Run("computes reliability bins, ECE and MCE", func(t *testing.T) {
+		// Arrange: four predictions at 0.9 with 3 correct, two at 0.65 with both wrong.
+		logits := [][]float64{logitsFor(0.9), logitsFor(0.9), logitsFor(0.9), logitsFor(0.9), logitsFor(0.65), logitsFor(0.65)}
+		targets := []int{0, 0, 0, 1, 1, 1}
+
+		// Act
+		report, err := Calibration(logits, targets, 5)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 6, report.Samples)
+		assert.Equal(t, 0, report.Bins[0].Count)
+		assert.Equal(t, 2, report.Bins[3].Count)
+		assert.InDelta(t, 0.65, report.Bins[3].Confidence, 1e-12)
+		assert.Equal(t, 0.0, report.Bins[3].Accuracy)
+		assert.Equal(t, 4, report.Bins[4].Count)
+		assert.InDelta(t, 0.75, report.Bins[4].Accuracy, 1e-12)
+		assert.InDelta(t, 4.0/6*0.15+2.0/6*0.65, report.ECE, 1e-12)
+		assert.InDelta(t, 0.65, report.MCE, 1e-12)
+	})
+
+	t.Run("exports bins as CSV", func(t *testing.T) {
+		// Arrange
+		report, err := Calibration([][]float64{{0, 0}}, []int{0}, 2)
+		assert.NoError(t, err)
+		var buf bytes.Buffer
+
+		// Act
+		err = report.WriteCSV(&buf)
+
+		// Assert
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Equal(t, []string{
+			"lower,upper,count,confidence,accuracy,gap",
+			"0,0.5,0,0,0,0",
+			"0.5,1,1,0.5,1,0.5",
+		}, lines)
+	})
+
+	t.Run("rejects mismatched targets", func(t *testing.T) {
+		// Act
+		_, err := Calibration([][]float64{{0, 1}}, []int{2}, 10)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestFitTemperature(t *testing.T) {
+	t.Run("recovers the temperature of over-confident logits", func(t *testing.T) {
+		// Arrange: labels drawn from softmax(z), logits reported as 2.5z.
+		rng := rand.New(rand.NewPCG(4, 4))
+		logits := make([][]float64, 4000)
+		targets := make([]int, len(logits))
+		for idx := range logits {
+			z := []float64{rng.NormFloat64(), rng.NormFloat64(), rng.NormFloat64()}
+			p, _, err := network.SoftmaxWithStats(z)
+			assert.NoError(t, err)
+			u := rng.Float64()
+			for targets[idx] = 0; targets[idx] < 2 && u > p[targets[idx]]; targets[idx]++ {
+				u -= p[targets[idx]]
+			}
+			logits[idx] = []float64{2.5 * z[0], 2.5 * z[1], 2.5 * z[2]}
+		}
+
+		// Act
+		temperature, err := FitTemperature(logits, targets)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 2.5, temperature, 0.2)
+
+		scaled, err := ScaleTemperature(logits, temperature)
+		assert.NoError(t, err)
+		before, err := MeanNLL(logits, targets)
+		assert.NoError(t, err)
+		after, err := MeanNLL(scaled, targets)
+		assert.NoError(t, err)
+		assert.Less(t, after, before)
+		for _, nearby := range []float64{0.9, 1.1} {
+			other, err := ScaleTemperature(logits, temperature*nearby)
+			assert.NoError(t, err)
+			nll, err := MeanNLL(other, targets)
+			assert.NoError(t, err)
+			assert.Greater(t, nll, after)
+		}
+	})
+
+	t.Run("fits MLP logits collected from a dataset", func(t *testing.T) {
+		// Arrange
+		model := lookupModel{{2, 0}, {0, 2}, {2, 0}, {0, 2}}
+		logits, err := CollectLogits(model, lookupDataset(0, 1, 1, 1))
+		assert.NoError(t, err)
+
+		// Act
+		temperature, err := FitTemperature(logits, []int{0, 1, 1, 1})
+
+		// Assert: 3 of 4 correct, so the fitted confidence is 0.75.
+		assert.NoError(t, err)
+		assert.InDelta(t, 2/math.Log(3), temperature, 1e-6)
+	})
+
+	t.Run("rejects non-positive temperatures", func(t *testing.T) {
+		// Act
+		_, err := ScaleTemperature([][]float64{{1}}, 0)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
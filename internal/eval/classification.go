@@ -0,0 +1,176 @@
+// Package eval measures trained models on held-out data.
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/obarker94/ml-doe/internal/network"
+)
+
+// Model is anything producing one score per class for an input, e.g. an
+// *network.MLP. Scores may be logits or probabilities; only their order
+// matters for classification metrics.
+type Model interface {
+	Forward(x []float64) ([]float64, error)
+}
+
+// Dataset pairs each input with its target class.
+type Dataset struct {
+	Inputs  [][]float64
+	Targets []int
+}
+
+func (d Dataset) Validate() error {
+	if len(d.Inputs) == 0 {
+		return fmt.Errorf("dataset must have samples")
+	}
+	if len(d.Inputs) != len(d.Targets) {
+		return fmt.Errorf("dimension mismatch: %d inputs and %d targets", len(d.Inputs), len(d.Targets))
+	}
+
+	return nil
+}
+
+// Config controls Evaluate. TopK defaults to 5, capped at the number of
+// classes.
+type Config struct {
+	TopK int
+}
+
+// ClassMetrics are the one-vs-rest scores of a single class. Support is the
+// number of samples whose target is the class. Undefined ratios (no
+// predictions or no support) are reported as 0.
+type ClassMetrics struct {
+	Class     int
+	Precision float64
+	Recall    float64
+	F1        float64
+	Support   int
+}
+
+// Average is an aggregate of per-class precision, recall and F1.
+type Average struct {
+	Precision float64
+	Recall    float64
+	F1        float64
+}
+
+// Report is the result of Evaluate.
+type Report struct {
+	Samples      int
+	Accuracy     float64
+	TopK         int
+	TopKAccuracy float64
+	PerClass     []ClassMetrics
+	// Macro is the unweighted mean over classes; Micro pools the counts of
+	// every class first, so for single-label data it equals Accuracy.
+	Macro     Average
+	Micro     Average
+	Confusion ConfusionMatrix
+}
+
+// Evaluate runs model over every sample of data and scores the predictions.
+// The number of classes is the model's output size. Models implementing
+// network.TrainingMode are switched to evaluation mode, so dropout and batch
+// norm are deterministic, and restored to their previous mode on return.
+func Evaluate(model Model, data Dataset, cfg Config) (Report, error) {
+	if err := data.Validate(); err != nil {
+		return Report{}, err
+	}
+	defer evaluationMode(model)()
+
+	var confusion ConfusionMatrix
+	topKHits := 0
+	k := cfg.TopK
+	if k == 0 {
+		k = 5
+	}
+	if k < 0 {
+		return Report{}, fmt.Errorf("TopK must be non-negative, got %d", cfg.TopK)
+	}
+
+	for idx, x := range data.Inputs {
+		scores, err := model.Forward(x)
+		if err != nil {
+			return Report{}, errors.Join(fmt.Errorf("model failed on sample %d", idx), err)
+		}
+
+		if idx == 0 {
+			confusion = NewConfusionMatrix(len(scores))
+			k = min(k, len(scores))
+		}
+		if len(scores) != confusion.Classes() {
+			return Report{}, fmt.Errorf("dimension mismatch: sample %d has %d scores, expected %d", idx, len(scores), confusion.Classes())
+		}
+
+		target := data.Targets[idx]
+		if target < 0 || target >= len(scores) {
+			return Report{}, fmt.Errorf("target %d of sample %d out of range for %d classes", target, idx, len(scores))
+		}
+
+		predicted, err := network.ArgMax(scores)
+		if err != nil {
+			return Report{}, errors.Join(fmt.Errorf("unable to predict sample %d", idx), err)
+		}
+		confusion.Counts[target][predicted]++
+
+		if inTopK(scores, target, k) {
+			topKHits++
+		}
+	}
+
+	report := confusion.Report()
+	report.TopK = k
+	report.TopKAccuracy = float64(topKHits) / float64(report.Samples)
+
+	return report, nil
+}
+
+// inTopK reports whether target is among the k highest scores, breaking ties
+// by index as ArgMax does.
+func inTopK(scores []float64, target, k int) bool {
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+
+	for _, class := range order[:k] {
+		if class == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ratio(num, den int) float64 {
+	if den == 0 {
+		return 0
+	}
+
+	return float64(num) / float64(den)
+}
+
+func f1(precision, recall float64) float64 {
+	if precision+recall == 0 {
+		return 0
+	}
+
+	return 2 * precision * recall / (precision + recall)
+}
+
+// evaluationMode switches model to evaluation if it has a training mode and
+// returns a func restoring the mode it was in.
+func evaluationMode(model any) func() {
+	m, ok := model.(network.TrainingMode)
+	if !ok {
+		return func() {}
+	}
+	training := m.Training()
+	m.SetTraining(false)
+
+	return func() { m.SetTraining(training) }
+}
@@ -0,0 +1,131 @@
+package eval
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/obarker94/ml-doe/internal/network"
+	"github.com/stretchr/testify/assert"
+)
+
+// lookupModel returns the scores stored at index x[0].
+type lookupModel [][]float64
+
+func (m lookupModel) Forward(x []float64) ([]float64, error) {
+	idx := int(x[0])
+	if idx < 0 || idx >= len(m) {
+		return nil, errors.New("no scores for input")
+	}
+	return m[idx], nil
+}
+
+func lookupDataset(targets ...int) Dataset {
+	inputs := make([][]float64, len(targets))
+	for i := range inputs {
+		inputs[i] = []float64{float64(i)}
+	}
+	return Dataset{Inputs: inputs, Targets: targets}
+}
+
+func TestEvaluate(t *testing.T) {
+	// predictions: 0, 1, 1, 2, 0
+	model := lookupModel{
+		{0.7, 0.2, 0.1},
+		{0.1, 0.8, 0.1},
+		{0.3, 0.6, 0.1},
+		{0.2, 0.3, 0.5},
+		{0.5, 0.1, 0.4},
+	}
+	data := lookupDataset(0, 1, 0, 2, 2)
+
+	t.Run("computes accuracy and top-k accuracy", func(t *testing.T) {
+		// Act
+		report, err := Evaluate(model, data, Config{TopK: 2})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 5, report.Samples)
+		assert.InDelta(t, 0.6, report.Accuracy, 1e-12)
+		assert.Equal(t, 2, report.TopK)
+		assert.InDelta(t, 1.0, report.TopKAccuracy, 1e-12)
+	})
+
+	t.Run("computes per-class, macro and micro scores", func(t *testing.T) {
+		// Act
+		report, err := Evaluate(model, data, Config{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, report.TopK)
+		assert.Equal(t, [][]int{{1, 1, 0}, {0, 1, 0}, {1, 0, 1}}, report.Confusion.Counts)
+		assert.Equal(t, ClassMetrics{Class: 0, Precision: 0.5, Recall: 0.5, F1: 0.5, Support: 2}, report.PerClass[0])
+		assert.InDelta(t, 0.5, report.PerClass[1].Precision, 1e-12)
+		assert.InDelta(t, 1.0, report.PerClass[1].Recall, 1e-12)
+		assert.InDelta(t, 2.0/3, report.PerClass[1].F1, 1e-12)
+		assert.InDelta(t, (0.5+0.5+1)/3, report.Macro.Precision, 1e-12)
+		assert.InDelta(t, (0.5+1+0.5)/3, report.Macro.Recall, 1e-12)
+		assert.InDelta(t, report.Accuracy, report.Micro.F1, 1e-12)
+	})
+
+	t.Run("evaluates an MLP", func(t *testing.T) {
+		// Arrange
+		mlp := &network.MLP{
+			Hidden: network.Block{
+				LinearLayer:  network.LinearLayer{In: 1, Out: 1, W: [][]float64{{1}}, B: []float64{0}},
+				Nonlinearity: network.ReLU{},
+			},
+			Out: network.LinearLayer{In: 1, Out: 2, W: [][]float64{{-1}, {1}}, B: []float64{0.5, -0.5}},
+		}
+		data := Dataset{Inputs: [][]float64{{0}, {2}, {3}}, Targets: []int{0, 1, 0}}
+
+		// Act
+		report, err := Evaluate(mlp, data, Config{TopK: 1})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 2.0/3, report.Accuracy, 1e-12)
+		assert.Equal(t, report.Accuracy, report.TopKAccuracy)
+	})
+
+	t.Run("evaluates a dropout MLP deterministically and restores training mode", func(t *testing.T) {
+		// Arrange
+		dropout, err := network.NewDropout(0.5, 3)
+		assert.NoError(t, err)
+		mlp := &network.MLP{
+			Hidden: network.Block{
+				LinearLayer:  network.LinearLayer{In: 1, Out: 2, W: [][]float64{{1}, {-1}}, B: []float64{0.1, 0.1}},
+				Nonlinearity: network.ReLU{},
+				Dropout:      dropout,
+			},
+			Out: network.LinearLayer{In: 2, Out: 2, W: [][]float64{{1, -1}, {-1, 1}}, B: []float64{0, 0}},
+		}
+		data := Dataset{Inputs: [][]float64{{1}, {-1}, {2}, {-2}, {0.5}, {-0.5}}, Targets: []int{0, 1, 0, 1, 0, 1}}
+
+		// Act
+		first, err1 := Evaluate(mlp, data, Config{TopK: 1})
+		second, err2 := Evaluate(mlp, data, Config{TopK: 1})
+		logits, err3 := CollectLogits(mlp, data)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.NoError(t, err3)
+		assert.True(t, dropout.Training())
+		assert.True(t, mlp.Training())
+		assert.Equal(t, 1.0, first.Accuracy)
+		assert.Equal(t, first, second)
+		assert.InDeltaSlice(t, []float64{1.1, -1.1}, logits[0], 1e-12)
+	})
+
+	t.Run("rejects out-of-range targets and mismatched datasets", func(t *testing.T) {
+		// Act
+		_, errTarget := Evaluate(model, lookupDataset(0, 3), Config{})
+		_, errLen := Evaluate(model, Dataset{Inputs: [][]float64{{0}}, Targets: []int{0, 1}}, Config{})
+		_, errModel := Evaluate(model, lookupDataset(0, 0, 0, 0, 0, 0), Config{})
+
+		// Assert
+		assert.Error(t, errTarget)
+		assert.Error(t, errLen)
+		assert.Error(t, errModel)
+	})
+}
@@ -0,0 +1,130 @@
+package eval
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/obarker94/ml-doe/internal"
+)
+
+// ConfusionMatrix counts predictions: Counts[target][predicted].
+type ConfusionMatrix struct {
+	Counts [][]int
+}
+
+func NewConfusionMatrix(classes int) ConfusionMatrix {
+	counts := make([][]int, classes)
+	for i := range counts {
+		counts[i] = make([]int, classes)
+	}
+
+	return ConfusionMatrix{Counts: counts}
+}
+
+func (m ConfusionMatrix) Classes() int {
+	return len(m.Counts)
+}
+
+// Report derives accuracy and precision/recall/F1 from the counts. TopK
+// fields are left zero as they need the raw scores.
+func (m ConfusionMatrix) Report() Report {
+	classes := m.Classes()
+	report := Report{PerClass: make([]ClassMetrics, classes), Confusion: m}
+
+	predicted := make([]int, classes)
+	correct, tpSum, fpSum, fnSum := 0, 0, 0, 0
+	for target, row := range m.Counts {
+		for pred, n := range row {
+			report.Samples += n
+			predicted[pred] += n
+		}
+		correct += row[target]
+	}
+
+	for class := range classes {
+		tp := m.Counts[class][class]
+		support := 0
+		for _, n := range m.Counts[class] {
+			support += n
+		}
+		fp, fn := predicted[class]-tp, support-tp
+		tpSum, fpSum, fnSum = tpSum+tp, fpSum+fp, fnSum+fn
+
+		c := ClassMetrics{Class: class, Precision: ratio(tp, tp+fp), Recall: ratio(tp, tp+fn), Support: support}
+		c.F1 = f1(c.Precision, c.Recall)
+		report.PerClass[class] = c
+
+		report.Macro.Precision += c.Precision / float64(classes)
+		report.Macro.Recall += c.Recall / float64(classes)
+		report.Macro.F1 += c.F1 / float64(classes)
+	}
+
+	report.Accuracy = ratio(correct, report.Samples)
+	report.Micro.Precision = ratio(tpSum, tpSum+fpSum)
+	report.Micro.Recall = ratio(tpSum, tpSum+fnSum)
+	report.Micro.F1 = f1(report.Micro.Precision, report.Micro.Recall)
+
+	return report
+}
+
+// Format renders the matrix as a right-aligned table with targets as rows and
+// predictions as columns. labels must have one entry per class; nil uses the
+// class indices.
+func (m ConfusionMatrix) Format(labels []string) (string, error) {
+	if labels == nil {
+		labels = make([]string, m.Classes())
+		for i := range labels {
+			labels[i] = strconv.Itoa(i)
+		}
+	}
+	if len(labels) != m.Classes() {
+		return "", fmt.Errorf("dimension mismatch: %d labels for %d classes", len(labels), m.Classes())
+	}
+
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 1, ' ', tabwriter.AlignRight)
+
+	fmt.Fprint(w, "true\\pred\t")
+	for _, label := range labels {
+		fmt.Fprintf(w, "%s\t", label)
+	}
+	fmt.Fprintln(w)
+
+	for target, row := range m.Counts {
+		fmt.Fprintf(w, "%s\t", labels[target])
+		for _, n := range row {
+			fmt.Fprintf(w, "%d\t", n)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// VocabLabels returns the symbol of each of the first classes ids of v, for
+// use with Format. Whitespace and non-printable symbols are quoted so the
+// table stays aligned.
+func VocabLabels(v *internal.Vocab, classes int) ([]string, error) {
+	labels := make([]string, classes)
+	for id := range labels {
+		symbol, err := v.Decode([]int{id})
+		if err != nil {
+			return nil, err
+		}
+
+		r := []rune(symbol)[0]
+		if unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			symbol = strconv.QuoteRune(r)
+		}
+		labels[id] = symbol
+	}
+
+	return labels, nil
+}
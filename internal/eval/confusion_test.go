@@ -0,0 +1,66 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/obarker94/ml-doe/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfusionMatrix(t *testing.T) {
+	t.Run("formats with vocab symbols as labels", func(t *testing.T) {
+		// Arrange
+		vocab, err := internal.NewVocab([]rune("ab "))
+		assert.NoError(t, err)
+		labels, err := VocabLabels(vocab, 3)
+		assert.NoError(t, err)
+		m := ConfusionMatrix{Counts: [][]int{{12, 0, 1}, {3, 7, 0}, {0, 0, 5}}}
+
+		// Act
+		out, err := m.Format(labels)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "' '"}, labels)
+		want := "" +
+			" true\\pred  a b ' '\n" +
+			"         a 12 0   1\n" +
+			"         b  3 7   0\n" +
+			"       ' '  0 0   5\n"
+		assert.Equal(t, want, out)
+	})
+
+	t.Run("defaults to class indices", func(t *testing.T) {
+		// Arrange
+		m := NewConfusionMatrix(2)
+		m.Counts[1][0] = 4
+
+		// Act
+		out, err := m.Format(nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, out, "1 4 0")
+	})
+
+	t.Run("rejects the wrong number of labels", func(t *testing.T) {
+		// Act
+		_, err := NewConfusionMatrix(2).Format([]string{"a"})
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("report handles classes that are never predicted", func(t *testing.T) {
+		// Arrange
+		m := ConfusionMatrix{Counts: [][]int{{2, 0}, {1, 0}}}
+
+		// Act
+		report := m.Report()
+
+		// Assert
+		assert.Equal(t, 0.0, report.PerClass[1].Precision)
+		assert.Equal(t, 0.0, report.PerClass[1].F1)
+		assert.Equal(t, 1, report.PerClass[1].Support)
+	})
+}
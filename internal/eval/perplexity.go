@@ -0,0 +1,154 @@
+package eval
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/obarker94/ml-doe/internal"
+	"github.com/obarker94/ml-doe/internal/network"
+)
+
+// LanguageModel returns next-token logits for every position of tokens: row
+// t predicts the token after tokens[t]. *network.Transformer and
+// *network.Recurrent satisfy it.
+type LanguageModel interface {
+	ForwardSequence(tokens []int) ([][]float64, error)
+}
+
+// StatefulModel is a LanguageModel that carries state from one
+// ForwardSequence to the next, such as *network.Recurrent. Perplexity resets
+// it before every window so overlapping windows are not fed twice.
+type StatefulModel interface {
+	LanguageModel
+	ResetState()
+}
+
+// PerplexityConfig controls the sliding window used by Perplexity.
+type PerplexityConfig struct {
+	// Context is the most tokens the model sees at once.
+	Context int
+	// Stride is how many new targets are scored per window, between 1 and
+	// Context. 1 gives every target a full context at one model call per
+	// token; Context scores whole windows at once. Defaults to 1.
+	Stride int
+}
+
+// PerplexityReport summarises the model's likelihood of a text.
+type PerplexityReport struct {
+	// Tokens is the number of scored targets; the first token of the text
+	// has no context and is not scored.
+	Tokens      int
+	NLL         float64 // mean negative log-likelihood, in nats
+	Perplexity  float64 // exp(NLL)
+	BitsPerChar float64 // NLL / ln 2
+}
+
+// Perplexity streams r through vocab.Encode and scores every token after the
+// first given up to cfg.Context preceding tokens. Log-likelihoods go through
+// LogSoftmax and NLLLoss so very unlikely tokens are not clamped.
+func Perplexity(model LanguageModel, vocab *internal.Vocab, r io.Reader, cfg PerplexityConfig) (PerplexityReport, error) {
+	if cfg.Stride == 0 {
+		cfg.Stride = 1
+	}
+	if cfg.Context <= 0 || cfg.Stride < 0 || cfg.Stride > cfg.Context {
+		return PerplexityReport{}, fmt.Errorf("invalid window: Context=%d Stride=%d", cfg.Context, cfg.Stride)
+	}
+
+	s := &perplexityStream{model: model, context: cfg.Context}
+	reader := bufio.NewReader(r)
+
+	for pos := 0; ; pos++ {
+		char, _, err := reader.ReadRune()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return PerplexityReport{}, errors.Join(errors.New("unable to read text"), err)
+		}
+
+		ids, err := vocab.Encode(string(char))
+		if err != nil {
+			return PerplexityReport{}, errors.Join(fmt.Errorf("unable to encode character at position %d", pos), err)
+		}
+
+		s.push(ids[0])
+		if s.pending == cfg.Stride {
+			if err := s.score(); err != nil {
+				return PerplexityReport{}, err
+			}
+		}
+	}
+
+	if s.pending > 0 {
+		if err := s.score(); err != nil {
+			return PerplexityReport{}, err
+		}
+	}
+	if s.tokens == 0 {
+		return PerplexityReport{}, fmt.Errorf("text must have at least two characters")
+	}
+
+	nll := s.total / float64(s.tokens)
+
+	return PerplexityReport{
+		Tokens:      s.tokens,
+		NLL:         nll,
+		Perplexity:  math.Exp(nll),
+		BitsPerChar: nll / math.Ln2,
+	}, nil
+}
+
+// perplexityStream keeps the last context+1 tokens, of which the final
+// pending ones are targets that have not been scored yet.
+type perplexityStream struct {
+	model   LanguageModel
+	context int
+
+	buf     []int
+	pending int
+	total   float64
+	tokens  int
+}
+
+func (s *perplexityStream) push(id int) {
+	s.buf = append(s.buf, id)
+	if len(s.buf) > s.context+1 {
+		s.buf = s.buf[len(s.buf)-s.context-1:]
+	}
+	if len(s.buf) > 1 {
+		s.pending++
+	}
+}
+
+func (s *perplexityStream) score() error {
+	input := s.buf[:len(s.buf)-1]
+	if stateful, ok := s.model.(StatefulModel); ok {
+		stateful.ResetState()
+	}
+	logits, err := s.model.ForwardSequence(input)
+	if err != nil {
+		return errors.Join(errors.New("language model failed"), err)
+	}
+	if len(logits) != len(input) {
+		return fmt.Errorf("dimension mismatch: model returned %d rows for %d tokens", len(logits), len(input))
+	}
+
+	for row := len(input) - s.pending; row < len(input); row++ {
+		logProbs, err := network.LogSoftmax(logits[row])
+		if err != nil {
+			return err
+		}
+		loss, err := network.NLLLoss(logProbs, s.buf[row+1])
+		if err != nil {
+			return err
+		}
+		s.total += loss
+		s.tokens++
+	}
+	s.pending = 0
+
+	return nil
+}
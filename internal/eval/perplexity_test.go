@@ -0,0 +1,149 @@
+package eval
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/obarker94/ml-doe/internal"
+	"github.com/obarker94/ml-doe/internal/network"
+	"github.com/stretchr/testify/assert"
+)
+
+// bigramModel puts logit Strength on the token after each input token, and
+// fails if given more than Context tokens.
+type bigramModel struct {
+	Vocab    int
+	Strength float64
+	Context  int
+	calls    int
+}
+
+func (m *bigramModel) ForwardSequence(tokens []int) ([][]float64, error) {
+	m.calls++
+	if m.Context > 0 && len(tokens) > m.Context {
+		return nil, fmt.Errorf("got %d tokens, context is %d", len(tokens), m.Context)
+	}
+	logits := make([][]float64, len(tokens))
+	for t, id := range tokens {
+		logits[t] = make([]float64, m.Vocab)
+		logits[t][(id+1)%m.Vocab] = m.Strength
+	}
+	return logits, nil
+}
+
+func TestPerplexity(t *testing.T) {
+	vocab, err := internal.NewVocab([]rune("abcd"))
+	assert.NoError(t, err)
+
+	t.Run("a uniform model has perplexity equal to the vocab size", func(t *testing.T) {
+		// Arrange
+		model := &bigramModel{Vocab: 4}
+
+		// Act
+		report, err := Perplexity(model, vocab, strings.NewReader("abcadbca"), PerplexityConfig{Context: 3})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 7, report.Tokens)
+		assert.InDelta(t, 4, report.Perplexity, 1e-9)
+		assert.InDelta(t, 2, report.BitsPerChar, 1e-9)
+	})
+
+	t.Run("scores every target exactly once for any stride", func(t *testing.T) {
+		// Arrange
+		text := "abcdabcaddcba"
+		want, err := Perplexity(&bigramModel{Vocab: 4, Strength: 2}, vocab, strings.NewReader(text), PerplexityConfig{Context: 4})
+		assert.NoError(t, err)
+
+		for _, stride := range []int{2, 3, 4} {
+			model := &bigramModel{Vocab: 4, Strength: 2, Context: 4}
+
+			// Act
+			got, err := Perplexity(model, vocab, strings.NewReader(text), PerplexityConfig{Context: 4, Stride: stride})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, want.Tokens, got.Tokens)
+			assert.InDelta(t, want.NLL, got.NLL, 1e-12)
+			assert.Equal(t, (len(text)-1+stride-1)/stride, model.calls)
+		}
+	})
+
+	t.Run("matches the loss of a predictable text", func(t *testing.T) {
+		// Arrange
+		model := &bigramModel{Vocab: 4, Strength: 3}
+		p := math.Exp(3) / (math.Exp(3) + 3)
+
+		// Act
+		report, err := Perplexity(model, vocab, strings.NewReader("abcdab"), PerplexityConfig{Context: 2})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, -math.Log(p), report.NLL, 1e-12)
+		assert.InDelta(t, 1/p, report.Perplexity, 1e-9)
+	})
+
+	t.Run("evaluates a transformer", func(t *testing.T) {
+		// Arrange
+		model, err := network.NewTransformer(network.TransformerConfig{Vocab: 4, Dim: 4, Heads: 2, Layers: 1, Context: 3, Seed: 1})
+		assert.NoError(t, err)
+
+		// Act
+		report, err := Perplexity(model, vocab, strings.NewReader("abcdabcd"), PerplexityConfig{Context: 3})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 7, report.Tokens)
+		assert.Greater(t, report.Perplexity, 1.0)
+	})
+
+	t.Run("starts a recurrent model from the zero state in every window", func(t *testing.T) {
+		// Arrange
+		rng := rand.New(rand.NewPCG(2, 2))
+		embedding, err := network.NewEmbedding(4, 3, rng)
+		assert.NoError(t, err)
+		cell, err := network.NewRNNCell(3, 5, rng)
+		assert.NoError(t, err)
+		out, err := network.NewLinearLayer(5, 4, true, network.XavierUniform, rng)
+		assert.NoError(t, err)
+		model, err := network.NewRecurrent(embedding, cell, out)
+		assert.NoError(t, err)
+
+		text := "abcdabcaddcb"
+		ids, err := vocab.Encode(text)
+		assert.NoError(t, err)
+		want := 0.0
+		for target := 1; target < len(ids); target++ {
+			model.ResetState()
+			logits, err := model.ForwardSequence(ids[max(0, target-3):target])
+			assert.NoError(t, err)
+			logProbs, err := network.LogSoftmax(logits[len(logits)-1])
+			assert.NoError(t, err)
+			loss, err := network.NLLLoss(logProbs, ids[target])
+			assert.NoError(t, err)
+			want += loss / float64(len(ids)-1)
+		}
+
+		// Act
+		report, err := Perplexity(model, vocab, strings.NewReader(text), PerplexityConfig{Context: 3})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, want, report.NLL, 1e-12)
+	})
+
+	t.Run("rejects unknown characters and texts too short to score", func(t *testing.T) {
+		// Act
+		_, errUnknown := Perplexity(&bigramModel{Vocab: 4}, vocab, strings.NewReader("abz"), PerplexityConfig{Context: 2})
+		_, errShort := Perplexity(&bigramModel{Vocab: 4}, vocab, strings.NewReader("a"), PerplexityConfig{Context: 2})
+		_, errWindow := Perplexity(&bigramModel{Vocab: 4}, vocab, strings.NewReader("ab"), PerplexityConfig{Context: 2, Stride: 3})
+
+		// Assert
+		assert.ErrorContains(t, errUnknown, "position 2")
+		assert.Error(t, errShort)
+		assert.Error(t, errWindow)
+	})
+}
@@ -0,0 +1,350 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+)
+
+// Metric scores a resample of a dataset given the indices of the samples it
+// contains (with repetition), e.g. accuracy over those predictions.
+type Metric func(indices []int) (float64, error)
+
+// ResampleConfig controls the bootstrap and permutation procedures.
+// Resamples defaults to 1000, Confidence to 0.95 and Alpha to 0.05.
+type ResampleConfig struct {
+	Resamples  int
+	Confidence float64
+	Alpha      float64
+	Seed       uint64
+}
+
+func (c ResampleConfig) withDefaults() (ResampleConfig, error) {
+	if c.Resamples == 0 {
+		c.Resamples = 1000
+	}
+	if c.Confidence == 0 {
+		c.Confidence = 0.95
+	}
+	if c.Alpha == 0 {
+		c.Alpha = 0.05
+	}
+	if c.Resamples < 0 || !(c.Confidence > 0 && c.Confidence < 1) || !(c.Alpha > 0 && c.Alpha < 1) {
+		return ResampleConfig{}, fmt.Errorf("invalid resample config: Resamples=%d Confidence=%v Alpha=%v", c.Resamples, c.Confidence, c.Alpha)
+	}
+
+	return c, nil
+}
+
+// Interval is a point estimate with a percentile bootstrap confidence
+// interval.
+type Interval struct {
+	Estimate   float64
+	Lower      float64
+	Upper      float64
+	Confidence float64
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("%.4f [%.4f, %.4f] (%.0f%% CI)", i.Estimate, i.Lower, i.Upper, 100*i.Confidence)
+}
+
+// BootstrapCI estimates metric over all n samples and its percentile
+// confidence interval over cfg.Resamples resamples drawn with replacement.
+func BootstrapCI(n int, metric Metric, cfg ResampleConfig) (Interval, error) {
+	if n <= 0 {
+		return Interval{}, fmt.Errorf("n must be positive, got %d", n)
+	}
+	cfg, err := cfg.withDefaults()
+	if err != nil {
+		return Interval{}, err
+	}
+
+	all := make([]int, n)
+	for i := range all {
+		all[i] = i
+	}
+	estimate, err := metric(all)
+	if err != nil {
+		return Interval{}, errors.Join(errors.New("metric failed on the full dataset"), err)
+	}
+
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+	scores := make([]float64, cfg.Resamples)
+	indices := make([]int, n)
+	for r := range scores {
+		for i := range indices {
+			indices[i] = rng.IntN(n)
+		}
+		scores[r], err = metric(indices)
+		if err != nil {
+			return Interval{}, errors.Join(fmt.Errorf("metric failed on resample %d", r), err)
+		}
+	}
+
+	lower, upper := percentileInterval(scores, cfg.Confidence)
+
+	return Interval{Estimate: estimate, Lower: lower, Upper: upper, Confidence: cfg.Confidence}, nil
+}
+
+// AccuracyMetric scores a resample by the fraction of correct predictions. It
+// returns an error for indices beyond correct, e.g. when BootstrapCI is given
+// an n larger than the dataset.
+func AccuracyMetric(correct []bool) Metric {
+	return func(indices []int) (float64, error) {
+		if len(indices) == 0 {
+			return 0, fmt.Errorf("resample must have samples")
+		}
+		hits := 0
+		for _, i := range indices {
+			if i < 0 || i >= len(correct) {
+				return 0, fmt.Errorf("sample %d out of range for %d predictions", i, len(correct))
+			}
+			if correct[i] {
+				hits++
+			}
+		}
+		return float64(hits) / float64(len(indices)), nil
+	}
+}
+
+// MeanMetric scores a resample by the mean of values, e.g. per-sample losses.
+func MeanMetric(values []float64) Metric {
+	return func(indices []int) (float64, error) {
+		if len(indices) == 0 {
+			return 0, fmt.Errorf("resample must have samples")
+		}
+		total := 0.0
+		for _, i := range indices {
+			if i < 0 || i >= len(values) {
+				return 0, fmt.Errorf("sample %d out of range for %d values", i, len(values))
+			}
+			total += values[i]
+		}
+		return total / float64(len(indices)), nil
+	}
+}
+
+// TestResult is the outcome of a paired significance test between models A
+// and B evaluated on the same samples.
+type TestResult struct {
+	Test string
+	// Difference is A minus B in the tested quantity (accuracy or mean loss).
+	Difference  float64
+	Statistic   float64
+	PValue      float64
+	Alpha       float64
+	Significant bool
+	// Interval is the bootstrap interval of Difference, set by
+	// PairedBootstrap only.
+	Interval *Interval
+}
+
+// String states the result in one line, e.g. for a training log.
+func (r TestResult) String() string {
+	verdict := "not significant"
+	if r.Significant {
+		verdict = "significant"
+	}
+
+	s := fmt.Sprintf("%s: difference (A - B) = %.4f, statistic = %.4f, p = %.4g: %s at alpha = %g", r.Test, r.Difference, r.Statistic, r.PValue, verdict, r.Alpha)
+	if r.Interval != nil {
+		s += fmt.Sprintf(", difference %s", r.Interval)
+	}
+
+	return s
+}
+
+// McNemar tests whether two classifiers have the same accuracy from which of
+// the paired predictions each got right. Only the discordant pairs matter:
+// with fewer than 25 it uses the exact binomial test, otherwise the
+// chi-squared statistic with continuity correction. alpha defaults to 0.05.
+func McNemar(correctA, correctB []bool, alpha float64) (TestResult, error) {
+	if len(correctA) == 0 || len(correctA) != len(correctB) {
+		return TestResult{}, fmt.Errorf("dimension mismatch: %d and %d paired predictions", len(correctA), len(correctB))
+	}
+	if alpha == 0 {
+		alpha = 0.05
+	}
+	if alpha <= 0 || alpha >= 1 || math.IsNaN(alpha) {
+		return TestResult{}, fmt.Errorf("alpha must be in (0, 1), got %v", alpha)
+	}
+
+	onlyA, onlyB := 0, 0
+	for i := range correctA {
+		switch {
+		case correctA[i] && !correctB[i]:
+			onlyA++
+		case correctB[i] && !correctA[i]:
+			onlyB++
+		}
+	}
+
+	result := TestResult{
+		Test:       "McNemar",
+		Difference: float64(onlyA-onlyB) / float64(len(correctA)),
+		Alpha:      alpha,
+		PValue:     1,
+	}
+
+	discordant := onlyA + onlyB
+	switch {
+	case discordant == 0:
+	case discordant < 25:
+		result.Test = "McNemar (exact)"
+		result.Statistic = float64(min(onlyA, onlyB))
+		result.PValue = math.Min(1, 2*binomialCDF(min(onlyA, onlyB), discordant))
+	default:
+		diff := math.Abs(float64(onlyA-onlyB)) - 1
+		result.Statistic = diff * diff / float64(discordant)
+		result.PValue = math.Erfc(math.Sqrt(result.Statistic / 2))
+	}
+	result.Significant = result.PValue < alpha
+
+	return result, nil
+}
+
+// PairedBootstrap tests whether the mean of lossA differs from that of lossB
+// by resampling the paired samples. The p-value is the share of resampled
+// differences, recentred on zero, at least as extreme as the observed one.
+func PairedBootstrap(lossA, lossB []float64, cfg ResampleConfig) (TestResult, error) {
+	diffs, err := pairedDifferences(lossA, lossB)
+	if err != nil {
+		return TestResult{}, err
+	}
+	cfg, err = cfg.withDefaults()
+	if err != nil {
+		return TestResult{}, err
+	}
+
+	observed := mean(diffs)
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+	samples := make([]float64, cfg.Resamples)
+	extreme := 0
+	for r := range samples {
+		total := 0.0
+		for range diffs {
+			total += diffs[rng.IntN(len(diffs))]
+		}
+		samples[r] = total / float64(len(diffs))
+		if math.Abs(samples[r]-observed) >= math.Abs(observed) {
+			extreme++
+		}
+	}
+
+	lower, upper := percentileInterval(samples, cfg.Confidence)
+	p := float64(extreme+1) / float64(cfg.Resamples+1)
+
+	return TestResult{
+		Test:        "paired bootstrap",
+		Difference:  observed,
+		Statistic:   observed,
+		PValue:      p,
+		Alpha:       cfg.Alpha,
+		Significant: p < cfg.Alpha,
+		Interval:    &Interval{Estimate: observed, Lower: lower, Upper: upper, Confidence: cfg.Confidence},
+	}, nil
+}
+
+// PermutationTest tests whether lossA and lossB have the same mean by
+// randomly swapping each pair, i.e. flipping the sign of its difference.
+func PermutationTest(lossA, lossB []float64, cfg ResampleConfig) (TestResult, error) {
+	diffs, err := pairedDifferences(lossA, lossB)
+	if err != nil {
+		return TestResult{}, err
+	}
+	cfg, err = cfg.withDefaults()
+	if err != nil {
+		return TestResult{}, err
+	}
+
+	observed := mean(diffs)
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+	extreme := 0
+	for range cfg.Resamples {
+		total := 0.0
+		for _, d := range diffs {
+			if rng.IntN(2) == 0 {
+				d = -d
+			}
+			total += d
+		}
+		if math.Abs(total/float64(len(diffs))) >= math.Abs(observed) {
+			extreme++
+		}
+	}
+
+	p := float64(extreme+1) / float64(cfg.Resamples+1)
+
+	return TestResult{
+		Test:        "paired permutation",
+		Difference:  observed,
+		Statistic:   observed,
+		PValue:      p,
+		Alpha:       cfg.Alpha,
+		Significant: p < cfg.Alpha,
+	}, nil
+}
+
+func pairedDifferences(a, b []float64) ([]float64, error) {
+	if len(a) == 0 || len(a) != len(b) {
+		return nil, fmt.Errorf("dimension mismatch: %d and %d paired losses", len(a), len(b))
+	}
+
+	diffs := make([]float64, len(a))
+	for i := range a {
+		if math.IsNaN(a[i]) || math.IsInf(a[i], 0) || math.IsNaN(b[i]) || math.IsInf(b[i], 0) {
+			return nil, fmt.Errorf("loss pair %d is invalid: %v, %v", i, a[i], b[i])
+		}
+		diffs[i] = a[i] - b[i]
+	}
+
+	return diffs, nil
+}
+
+func mean(v []float64) float64 {
+	total := 0.0
+	for _, x := range v {
+		total += x
+	}
+
+	return total / float64(len(v))
+}
+
+// percentileInterval returns the central confidence interval of samples,
+// sorting them in place.
+func percentileInterval(samples []float64, confidence float64) (float64, float64) {
+	sort.Float64s(samples)
+	tail := (1 - confidence) / 2
+
+	return quantile(samples, tail), quantile(samples, 1-tail)
+}
+
+// quantile linearly interpolates the q-quantile of sorted.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := min(lo+1, len(sorted)-1)
+
+	return sorted[lo] + (pos-float64(lo))*(sorted[hi]-sorted[lo])
+}
+
+// binomialCDF is P(X <= k) for X ~ Binomial(n, 1/2).
+func binomialCDF(k, n int) float64 {
+	total := 0.0
+	for i := 0; i <= k; i++ {
+		total += math.Exp(logChoose(n, i) - float64(n)*math.Ln2)
+	}
+
+	return total
+}
+
+func logChoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+
+	return a - b - c
+}
@@ -0,0 +1,187 @@
+package eval
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootstrapCI(t *testing.T) {
+	t.Run("brackets the accuracy estimate", func(t *testing.T) {
+		// Arrange
+		correct := make([]bool, 200)
+		for i := range correct {
+			correct[i] = i%4 != 0
+		}
+
+		// Act
+		ci, err := BootstrapCI(len(correct), AccuracyMetric(correct), ResampleConfig{Seed: 1})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0.75, ci.Estimate)
+		assert.Less(t, ci.Lower, 0.75)
+		assert.Greater(t, ci.Upper, 0.75)
+		// Normal approximation half-width: 1.96 * sqrt(0.75*0.25/200) ≈ 0.06.
+		assert.InDelta(t, 0.06, (ci.Upper-ci.Lower)/2, 0.015)
+		assert.Equal(t, 0.95, ci.Confidence)
+	})
+
+	t.Run("is deterministic for a seed", func(t *testing.T) {
+		// Arrange
+		losses := []float64{0.3, 1.2, 0.8, 0.1, 2.4, 0.7}
+
+		// Act
+		ci1, err1 := BootstrapCI(len(losses), MeanMetric(losses), ResampleConfig{Seed: 3, Resamples: 200})
+		ci2, err2 := BootstrapCI(len(losses), MeanMetric(losses), ResampleConfig{Seed: 3, Resamples: 200})
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, ci1, ci2)
+	})
+
+	t.Run("rejects n larger than the metric's data", func(t *testing.T) {
+		// Act
+		_, errAccuracy := BootstrapCI(5, AccuracyMetric([]bool{true, false}), ResampleConfig{})
+		_, errMean := BootstrapCI(5, MeanMetric([]float64{1, 2}), ResampleConfig{})
+
+		// Assert
+		assert.Error(t, errAccuracy)
+		assert.Error(t, errMean)
+	})
+
+	t.Run("rejects invalid confidence", func(t *testing.T) {
+		// Act
+		_, err := BootstrapCI(3, MeanMetric([]float64{1, 2, 3}), ResampleConfig{Confidence: 1.5})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestMcNemar(t *testing.T) {
+	t.Run("uses the exact test for few discordant pairs", func(t *testing.T) {
+		// Arrange: A alone right 8 times, B alone right once.
+		a := make([]bool, 20)
+		b := make([]bool, 20)
+		for i := range 8 {
+			a[i] = true
+		}
+		b[8] = true
+
+		// Act
+		result, err := McNemar(a, b, 0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "McNemar (exact)", result.Test)
+		assert.InDelta(t, 2*10.0/512, result.PValue, 1e-12)
+		assert.True(t, result.Significant)
+		assert.InDelta(t, 7.0/20, result.Difference, 1e-12)
+	})
+
+	t.Run("uses the chi-squared test for many discordant pairs", func(t *testing.T) {
+		// Arrange: 20 vs 10 discordant pairs.
+		a := make([]bool, 100)
+		b := make([]bool, 100)
+		for i := range 20 {
+			a[i] = true
+		}
+		for i := 20; i < 30; i++ {
+			b[i] = true
+		}
+
+		// Act
+		result, err := McNemar(a, b, 0.05)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 81.0/30, result.Statistic, 1e-12)
+		assert.InDelta(t, 0.1003, result.PValue, 1e-4)
+		assert.False(t, result.Significant)
+		assert.Contains(t, result.String(), "not significant at alpha = 0.05")
+	})
+
+	t.Run("rejects alpha outside (0, 1)", func(t *testing.T) {
+		for _, alpha := range []float64{-1, 1, 1.5, math.NaN()} {
+			// Act
+			_, err := McNemar([]bool{true, false}, []bool{false, true}, alpha)
+
+			// Assert
+			assert.Error(t, err, "alpha %v", alpha)
+		}
+	})
+
+	t.Run("identical models are not different", func(t *testing.T) {
+		// Act
+		result, err := McNemar([]bool{true, false}, []bool{true, false}, 0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, result.PValue)
+		assert.False(t, result.Significant)
+	})
+}
+
+func TestPairedLossTests(t *testing.T) {
+	rng := rand.New(rand.NewPCG(2, 2))
+	lossB := make([]float64, 300)
+	better := make([]float64, len(lossB))
+	similar := make([]float64, len(lossB))
+	for i := range lossB {
+		lossB[i] = 1 + 0.5*rng.NormFloat64()
+		better[i] = lossB[i] - 0.1 + 0.1*rng.NormFloat64()
+		similar[i] = lossB[i] + 0.1*rng.NormFloat64()
+	}
+
+	for name, test := range map[string]func(a, b []float64, cfg ResampleConfig) (TestResult, error){
+		"paired bootstrap":   PairedBootstrap,
+		"paired permutation": PermutationTest,
+	} {
+		t.Run(name+" detects a consistent improvement", func(t *testing.T) {
+			// Act
+			result, err := test(better, lossB, ResampleConfig{Seed: 7})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, name, result.Test)
+			assert.InDelta(t, -0.1, result.Difference, 0.03)
+			assert.True(t, result.Significant)
+			assert.Less(t, result.PValue, 0.01)
+			assert.Contains(t, result.String(), ": significant")
+		})
+
+		t.Run(name+" does not flag noise", func(t *testing.T) {
+			// Act
+			result, err := test(similar, lossB, ResampleConfig{Seed: 7})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Greater(t, result.PValue, 0.05)
+			assert.False(t, result.Significant)
+		})
+	}
+
+	t.Run("paired bootstrap reports the interval of the difference", func(t *testing.T) {
+		// Act
+		result, err := PairedBootstrap(better, lossB, ResampleConfig{Seed: 7})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, result.Interval)
+		assert.Less(t, result.Interval.Upper, 0.0)
+	})
+
+	t.Run("rejects unpaired or invalid losses", func(t *testing.T) {
+		// Act
+		_, errLen := PermutationTest([]float64{1}, []float64{1, 2}, ResampleConfig{})
+		_, errNaN := PairedBootstrap([]float64{math.NaN()}, []float64{1}, ResampleConfig{})
+
+		// Assert
+		assert.Error(t, errLen)
+		assert.Error(t, errNaN)
+	})
+}
@@ -0,0 +1,128 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// UnitStats describes a single hidden unit over the observed samples.
+type UnitStats struct {
+	Unit           int
+	ActivationRate float64 // fraction of samples where the activation was > 0
+	PreMean        float64
+	PreStd         float64
+}
+
+// Dead reports whether the unit never fired.
+func (u UnitStats) Dead() bool {
+	return u.ActivationRate == 0
+}
+
+// ActivationMonitor tracks, per unit of a Block, how often the unit fires and
+// the distribution of its pre-activations. Run a dataset through the block to
+// find dead ReLUs: units whose activation is never positive.
+type ActivationMonitor struct {
+	samples int
+	fired   []int
+	sum     []float64
+	sumSq   []float64
+}
+
+// NewActivationMonitor attaches a monitor to b through a forward hook on the
+// block. Pre-activations are the nonlinearity inputs, after any Norm, and a
+// sample only counts once the whole block forward has succeeded.
+func NewActivationMonitor(b *Block) (*ActivationMonitor, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	units := b.LinearLayer.Out
+	m := &ActivationMonitor{
+		fired: make([]int, units),
+		sum:   make([]float64, units),
+		sumSq: make([]float64, units),
+	}
+
+	b.RegisterForwardHook(func(_, out []float64) {
+		m.samples++
+		for i, v := range b.pre {
+			m.sum[i] += v
+			m.sumSq[i] += v * v
+		}
+		for i, v := range out {
+			if v > 0 {
+				m.fired[i]++
+			}
+		}
+	})
+
+	return m, nil
+}
+
+// Samples is the number of forward passes observed.
+func (m *ActivationMonitor) Samples() int {
+	return m.samples
+}
+
+// Stats returns per-unit statistics. All values are 0 before any samples.
+func (m *ActivationMonitor) Stats() []UnitStats {
+	stats := make([]UnitStats, len(m.fired))
+	for i := range stats {
+		stats[i].Unit = i
+		if m.samples == 0 {
+			continue
+		}
+
+		n := float64(m.samples)
+		mean := m.sum[i] / n
+		stats[i].ActivationRate = float64(m.fired[i]) / n
+		stats[i].PreMean = mean
+		stats[i].PreStd = math.Sqrt(max(m.sumSq[i]/n-mean*mean, 0))
+	}
+
+	return stats
+}
+
+// DeadFraction is the fraction of units that never fired.
+func (m *ActivationMonitor) DeadFraction() float64 {
+	if m.samples == 0 {
+		return 0
+	}
+
+	dead := 0
+	for _, count := range m.fired {
+		if count == 0 {
+			dead++
+		}
+	}
+
+	return float64(dead) / float64(len(m.fired))
+}
+
+// Report renders the per-unit statistics as a text table.
+func (m *ActivationMonitor) Report() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "activations over %d samples: %.1f%% of %d units dead\n",
+		m.samples, 100*m.DeadFraction(), len(m.fired))
+	fmt.Fprintf(&sb, "%-6s %-8s %-10s %-10s\n", "unit", "rate", "pre-mean", "pre-std")
+
+	for _, u := range m.Stats() {
+		fmt.Fprintf(&sb, "%-6d %-8.3f %-10.4f %-10.4f", u.Unit, u.ActivationRate, u.PreMean, u.PreStd)
+		if m.samples > 0 && u.Dead() {
+			sb.WriteString(" dead")
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// Reset clears the accumulated statistics while keeping the hooks attached.
+func (m *ActivationMonitor) Reset() {
+	m.samples = 0
+	clear(m.fired)
+	clear(m.sum)
+	clear(m.sumSq)
+}
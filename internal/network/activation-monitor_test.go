@@ -0,0 +1,138 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivationMonitor(t *testing.T) {
+	newBlock := func() *Block {
+		return &Block{
+			LinearLayer: LinearLayer{
+				In:  1,
+				Out: 3,
+				// unit 0 fires for positive x, unit 1 for negative x and unit
+				// 2 never fires.
+				W: [][]float64{{1}, {-1}, {1}},
+				B: []float64{0, 0, -100},
+			},
+			Nonlinearity: ReLU{},
+		}
+	}
+
+	t.Run("tracks activation rates and pre-activation moments", func(t *testing.T) {
+		// Arrange
+		block := newBlock()
+		m, err := NewActivationMonitor(block)
+		assert.NoError(t, err)
+
+		// Act
+		for _, x := range []float64{1, 2, 3, -2} {
+			_, err := block.Forward([]float64{x})
+			assert.NoError(t, err)
+		}
+		stats := m.Stats()
+
+		// Assert
+		assert.Equal(t, 4, m.Samples())
+		assert.InDelta(t, 0.75, stats[0].ActivationRate, 1e-12)
+		assert.InDelta(t, 0.25, stats[1].ActivationRate, 1e-12)
+		assert.True(t, stats[2].Dead())
+		assert.InDelta(t, 1.0, stats[0].PreMean, 1e-12)
+		assert.InDelta(t, math.Sqrt(3.5), stats[0].PreStd, 1e-12)
+		assert.InDelta(t, 1.0/3, m.DeadFraction(), 1e-12)
+	})
+
+	t.Run("Report flags dead units", func(t *testing.T) {
+		// Arrange
+		block := newBlock()
+		m, err := NewActivationMonitor(block)
+		assert.NoError(t, err)
+		_, err = block.Forward([]float64{1})
+		assert.NoError(t, err)
+
+		// Act
+		report := m.Report()
+
+		// Assert
+		assert.Contains(t, report, "activations over 1 samples")
+		assert.Contains(t, report, "dead")
+	})
+
+	t.Run("Reset clears statistics but keeps monitoring", func(t *testing.T) {
+		// Arrange
+		block := newBlock()
+		m, err := NewActivationMonitor(block)
+		assert.NoError(t, err)
+		_, err = block.Forward([]float64{1})
+		assert.NoError(t, err)
+
+		// Act
+		m.Reset()
+		_, err = block.Forward([]float64{-1})
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, 1, m.Samples())
+		assert.InDelta(t, 0.0, m.Stats()[0].ActivationRate, 1e-12)
+		assert.InDelta(t, 1.0, m.Stats()[1].ActivationRate, 1e-12)
+	})
+
+	t.Run("measures pre-activations after the block norm", func(t *testing.T) {
+		// Arrange
+		norm, err := NewLayerNorm(2)
+		assert.NoError(t, err)
+		block := &Block{
+			LinearLayer:  LinearLayer{In: 1, Out: 2, W: [][]float64{{1}, {-1}}, B: []float64{0, 0}},
+			Norm:         norm,
+			Nonlinearity: ReLU{},
+		}
+		m, err := NewActivationMonitor(block)
+		assert.NoError(t, err)
+
+		// Act
+		for _, x := range []float64{1, 2, 3, -2} {
+			_, err := block.Forward([]float64{x})
+			assert.NoError(t, err)
+		}
+		stats := m.Stats()
+
+		// Assert: LayerNorm maps every sample to roughly (±1, ∓1).
+		assert.InDelta(t, 0.5, stats[0].PreMean, 1e-4)
+		assert.InDelta(t, math.Sqrt(0.75), stats[0].PreStd, 1e-4)
+		assert.InDelta(t, -0.5, stats[1].PreMean, 1e-4)
+		assert.InDelta(t, 0.25, stats[1].ActivationRate, 1e-12)
+	})
+
+	t.Run("does not count forwards that fail after the linear layer", func(t *testing.T) {
+		// Arrange
+		norm, err := NewBatchNorm1d(3)
+		assert.NoError(t, err)
+		block := newBlock()
+		block.Norm = norm
+		m, err := NewActivationMonitor(block)
+		assert.NoError(t, err)
+
+		// Act
+		_, errSingle := block.Forward([]float64{1})
+		_, errBatch := block.ForwardBatch([][]float64{{1}})
+		_, err = block.ForwardBatch([][]float64{{1}, {-1}})
+
+		// Assert
+		assert.Error(t, errSingle)
+		assert.Error(t, errBatch)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, m.Samples())
+		assert.InDelta(t, 0.5, m.Stats()[0].ActivationRate, 1e-12)
+	})
+
+	t.Run("returns an error for an invalid block", func(t *testing.T) {
+		// Act
+		_, err := NewActivationMonitor(&Block{})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,219 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// CausalSelfAttention is multi-head scaled dot-product attention in which
+// position t only attends to positions 0..t. Each head works on its own
+// Dim/Heads slice of the Query, Key and Value projections, and the heads are
+// concatenated before Proj.
+type CausalSelfAttention struct {
+	Dim   int
+	Heads int
+	Query *LinearLayer
+	Key   *LinearLayer
+	Value *LinearLayer
+	Proj  *LinearLayer
+
+	q, k, v [][]float64
+	// weights[h][t] are head h's attention probabilities for position t, zero
+	// for the masked positions after t.
+	weights [][][]float64
+}
+
+func NewCausalSelfAttention(dim, heads int, rng *rand.Rand) (*CausalSelfAttention, error) {
+	if heads <= 0 || dim <= 0 || dim%heads != 0 {
+		return nil, fmt.Errorf("dim %d must be a positive multiple of heads %d", dim, heads)
+	}
+
+	layers := make([]*LinearLayer, 4)
+	for i := range layers {
+		l, err := NewLinearLayer(dim, dim, true, XavierUniform, rng)
+		if err != nil {
+			return nil, errors.Join(errors.New("unable to create attention projection"), err)
+		}
+		layers[i] = l
+	}
+
+	return &CausalSelfAttention{Dim: dim, Heads: heads, Query: layers[0], Key: layers[1], Value: layers[2], Proj: layers[3]}, nil
+}
+
+func (a *CausalSelfAttention) Validate() error {
+	if a.Heads <= 0 || a.Dim <= 0 || a.Dim%a.Heads != 0 {
+		return fmt.Errorf("dim %d must be a positive multiple of heads %d", a.Dim, a.Heads)
+	}
+	for _, l := range []*LinearLayer{a.Query, a.Key, a.Value, a.Proj} {
+		if l == nil {
+			return fmt.Errorf("query, key, value and proj are required")
+		}
+		if l.In != a.Dim || l.Out != a.Dim {
+			return fmt.Errorf("dimension mismatch: projection is %dx%d, expected %dx%d", l.Out, l.In, a.Dim, a.Dim)
+		}
+	}
+
+	return nil
+}
+
+// ForwardSequence attends over the rows of X and returns one row per position.
+func (a *CausalSelfAttention) ForwardSequence(X [][]float64) ([][]float64, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	if len(X) == 0 {
+		return nil, fmt.Errorf("sequence must have length")
+	}
+
+	q, err := a.Query.ForwardBatch(X)
+	if err != nil {
+		return nil, errors.Join(errors.New("attention unable to project queries"), err)
+	}
+	k, err := a.Key.ForwardBatch(X)
+	if err != nil {
+		return nil, errors.Join(errors.New("attention unable to project keys"), err)
+	}
+	v, err := a.Value.ForwardBatch(X)
+	if err != nil {
+		return nil, errors.Join(errors.New("attention unable to project values"), err)
+	}
+
+	size := a.Dim / a.Heads
+	scale := 1 / math.Sqrt(float64(size))
+	heads := make([][]float64, len(X))
+	for t := range heads {
+		heads[t] = make([]float64, a.Dim)
+	}
+	weights := make([][][]float64, a.Heads)
+
+	for h := range a.Heads {
+		lo, hi := h*size, (h+1)*size
+		weights[h] = make([][]float64, len(X))
+
+		for t := range X {
+			mask := CausalMask(t, len(X))
+			scores := make([]float64, len(X))
+			for s := range scores {
+				if !mask[s] {
+					scores[s] = scale * dot(q[t][lo:hi], k[s][lo:hi])
+				}
+			}
+			p, _, err := MaskedSoftmax(scores, mask)
+			if err != nil {
+				return nil, errors.Join(fmt.Errorf("attention softmax failed for head %d position %d", h, t), err)
+			}
+			weights[h][t] = p
+
+			for s, w := range p {
+				for j := lo; j < hi; j++ {
+					heads[t][j] += w * v[s][j]
+				}
+			}
+		}
+	}
+
+	output, err := a.Proj.ForwardBatch(heads)
+	if err != nil {
+		return nil, errors.Join(errors.New("attention unable to project output"), err)
+	}
+
+	a.q, a.k, a.v, a.weights = q, k, v, weights
+
+	return output, nil
+}
+
+// BackwardSequence backpropagates through the last ForwardSequence and returns
+// the gradient with respect to its input rows.
+func (a *CausalSelfAttention) BackwardSequence(dY [][]float64) ([][]float64, error) {
+	if len(dY) != len(a.q) {
+		return nil, fmt.Errorf("dimension mismatch: dY has %d rows, last sequence had %d", len(dY), len(a.q))
+	}
+
+	dHeads, err := a.Proj.BackwardBatch(dY)
+	if err != nil {
+		return nil, errors.Join(errors.New("attention unable to backward output projection"), err)
+	}
+
+	size := a.Dim / a.Heads
+	scale := 1 / math.Sqrt(float64(size))
+	dq, dk, dv := zeroRows(len(dY), a.Dim), zeroRows(len(dY), a.Dim), zeroRows(len(dY), a.Dim)
+
+	for h := range a.Heads {
+		lo, hi := h*size, (h+1)*size
+
+		for t, p := range a.weights[h] {
+			// dp[s] = dHead_t · v_s, then through the softmax Jacobian.
+			dp := make([]float64, len(p))
+			weighted := 0.0
+			for s, w := range p {
+				dp[s] = dot(dHeads[t][lo:hi], a.v[s][lo:hi])
+				weighted += w * dp[s]
+				for j := lo; j < hi; j++ {
+					dv[s][j] += w * dHeads[t][j]
+				}
+			}
+
+			for s, w := range p {
+				dScore := scale * w * (dp[s] - weighted)
+				for j := lo; j < hi; j++ {
+					dq[t][j] += dScore * a.k[s][j]
+					dk[s][j] += dScore * a.q[t][j]
+				}
+			}
+		}
+	}
+
+	dX := zeroRows(len(dY), a.Dim)
+	for _, branch := range []struct {
+		layer *LinearLayer
+		dY    [][]float64
+	}{{a.Query, dq}, {a.Key, dk}, {a.Value, dv}} {
+		dx, err := branch.layer.BackwardBatch(branch.dY)
+		if err != nil {
+			return nil, errors.Join(errors.New("attention unable to backward input projections"), err)
+		}
+		for t := range dX {
+			addInto(dX[t], dx[t])
+		}
+	}
+
+	return dX, nil
+}
+
+// Weights returns head h's attention probabilities from the last forward pass,
+// one row per position.
+func (a *CausalSelfAttention) Weights(h int) ([][]float64, error) {
+	if h < 0 || h >= len(a.weights) {
+		return nil, fmt.Errorf("head %d out of range for %d heads from the last forward", h, len(a.weights))
+	}
+
+	return a.weights[h], nil
+}
+
+func (a *CausalSelfAttention) Params() []Param {
+	params := prefixParams("query", a.Query.Params())
+	params = append(params, prefixParams("key", a.Key.Params())...)
+	params = append(params, prefixParams("value", a.Value.Params())...)
+	return append(params, prefixParams("proj", a.Proj.Params())...)
+}
+
+func zeroRows(rows, cols int) [][]float64 {
+	m := make([][]float64, rows)
+	for i := range m {
+		m[i] = make([]float64, cols)
+	}
+
+	return m
+}
+
+// dot is internal.Dot for head slices whose lengths are equal by construction.
+func dot(a, b []float64) float64 {
+	total := 0.0
+	for i := range a {
+		total += a[i] * b[i]
+	}
+
+	return total
+}
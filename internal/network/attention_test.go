@@ -0,0 +1,102 @@
+package network
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSequence = [][]float64{{0.3, -1.2, 0.8, 0.1}, {1.1, 0.4, -0.6, 0.9}, {-0.5, 0.7, 0.2, -1.3}}
+
+func TestCausalSelfAttention(t *testing.T) {
+	newAttention := func(t *testing.T) *CausalSelfAttention {
+		a, err := NewCausalSelfAttention(4, 2, rand.New(rand.NewPCG(5, 5)))
+		assert.NoError(t, err)
+		return a
+	}
+
+	t.Run("earlier positions ignore later inputs", func(t *testing.T) {
+		// Arrange
+		a := newAttention(t)
+		changed := cloneMatrix(testSequence)
+		changed[2] = []float64{9, 9, 9, 9}
+
+		// Act
+		Y1, err1 := a.ForwardSequence(testSequence)
+		Y2, err2 := a.ForwardSequence(changed)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, Y1[:2], Y2[:2])
+		assert.NotEqual(t, Y1[2], Y2[2])
+	})
+
+	t.Run("each position attends over its prefix", func(t *testing.T) {
+		// Arrange
+		a := newAttention(t)
+		_, err := a.ForwardSequence(testSequence)
+		assert.NoError(t, err)
+
+		// Act
+		weights, err := a.Weights(1)
+
+		// Assert
+		assert.NoError(t, err)
+		for pos, row := range weights {
+			assert.Len(t, row, len(testSequence))
+			total := 0.0
+			for s, w := range row {
+				if s > pos {
+					assert.Equal(t, 0.0, w)
+				}
+				total += w
+			}
+			assert.InDelta(t, 1, total, 1e-12)
+		}
+	})
+
+	t.Run("gradients match finite differences", func(t *testing.T) {
+		// Arrange
+		a := newAttention(t)
+		X := cloneMatrix(testSequence)
+		dX := zeroRows(len(X), 4)
+		dY := [][]float64{{0.5, -1, 0.2, 0.7}, {-0.3, 0.8, 1.1, -0.4}, {0.9, 0.1, -0.6, 0.3}}
+
+		loss := func() (float64, error) {
+			Y, err := a.ForwardSequence(X)
+			total := 0.0
+			for t := range Y {
+				total += dot(Y[t], dY[t])
+			}
+			return total, err
+		}
+		backward := func() error {
+			if _, err := a.ForwardSequence(X); err != nil {
+				return err
+			}
+			grad, err := a.BackwardSequence(dY)
+			for t := range grad {
+				copy(dX[t], grad[t])
+			}
+			return err
+		}
+		params := append(a.Params(), Param{Name: "input", Value: X, Grad: dX})
+
+		// Act
+		report, err := GradCheckLoss(params, loss, backward, GradCheckConfig{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-5))
+	})
+
+	t.Run("rejects dims not divisible by heads", func(t *testing.T) {
+		// Act
+		_, err := NewCausalSelfAttention(5, 2, rand.New(rand.NewPCG(1, 1)))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,247 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// BatchNorm1d normalises each feature across a batch. While training it uses
+// the statistics of the current batch and updates RunningMean and RunningVar;
+// in evaluation it uses the running statistics, so single samples can be
+// passed through Forward.
+type BatchNorm1d struct {
+	Size    int
+	Gain    []float64
+	Bias    []float64
+	Epsilon float64
+	// Momentum is the weight of the current batch in the running statistics.
+	Momentum float64
+
+	RunningMean []float64
+	RunningVar  []float64
+
+	GradGain []float64
+	GradBias []float64
+
+	training bool
+
+	// Cached by the last forward pass for Backward.
+	xhat        [][]float64
+	invStd      []float64
+	batchStats  bool
+	singleInput bool
+}
+
+// NewBatchNorm1d creates a BatchNorm1d in training mode with Gain 1, Bias 0,
+// Epsilon 1e-5 and Momentum 0.1.
+func NewBatchNorm1d(size int) (*BatchNorm1d, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("batch norm size must be positive, got %d", size)
+	}
+
+	gain := make([]float64, size)
+	runningVar := make([]float64, size)
+	for i := range gain {
+		gain[i] = 1
+		runningVar[i] = 1
+	}
+
+	return &BatchNorm1d{
+		Size:        size,
+		Gain:        gain,
+		Bias:        make([]float64, size),
+		Epsilon:     1e-5,
+		Momentum:    0.1,
+		RunningMean: make([]float64, size),
+		RunningVar:  runningVar,
+		GradGain:    make([]float64, size),
+		GradBias:    make([]float64, size),
+		training:    true,
+	}, nil
+}
+
+func (n *BatchNorm1d) SetTraining(training bool) {
+	n.training = training
+}
+
+func (n *BatchNorm1d) Training() bool {
+	return n.training
+}
+
+func (n *BatchNorm1d) Validate() error {
+	if n.Size <= 0 {
+		return fmt.Errorf("batch norm size must be positive, got %d", n.Size)
+	}
+	for name, v := range map[string][]float64{"gain": n.Gain, "bias": n.Bias, "running mean": n.RunningMean, "running var": n.RunningVar} {
+		if len(v) != n.Size {
+			return fmt.Errorf("dimension mismatch: %s has length %d, expected %d", name, len(v), n.Size)
+		}
+	}
+	if !(n.Epsilon > 0) {
+		return fmt.Errorf("epsilon must be positive, got %v", n.Epsilon)
+	}
+	if !(n.Momentum >= 0 && n.Momentum <= 1) {
+		return fmt.Errorf("momentum must be in [0, 1], got %v", n.Momentum)
+	}
+
+	return nil
+}
+
+// Forward normalises a single sample with the running statistics. It is only
+// available in evaluation; use ForwardBatch while training.
+func (n *BatchNorm1d) Forward(x []float64) ([]float64, error) {
+	if n.training {
+		return nil, fmt.Errorf("BatchNorm1d needs ForwardBatch in training mode")
+	}
+
+	Y, err := n.ForwardBatch([][]float64{x})
+	if err != nil {
+		return nil, err
+	}
+	n.singleInput = true
+
+	return Y[0], nil
+}
+
+func (n *BatchNorm1d) Backward(dy []float64) ([]float64, error) {
+	if !n.singleInput {
+		return nil, fmt.Errorf("BatchNorm1d Backward called without a single-sample Forward")
+	}
+
+	dX, err := n.BackwardBatch([][]float64{dy})
+	if err != nil {
+		return nil, err
+	}
+
+	return dX[0], nil
+}
+
+func (n *BatchNorm1d) ForwardBatch(X [][]float64) ([][]float64, error) {
+	if err := n.Validate(); err != nil {
+		return nil, errors.Join(errors.New("BatchNorm1d failed validation"), err)
+	}
+	if len(X) == 0 {
+		return nil, fmt.Errorf("batch must have at least one row")
+	}
+	if n.training && len(X) < 2 {
+		return nil, fmt.Errorf("batch statistics need at least 2 rows in training mode, got %d", len(X))
+	}
+	for idx, x := range X {
+		if len(x) != n.Size {
+			return nil, fmt.Errorf("dimension mismatch: row %d has length %d, expected %d", idx, len(x), n.Size)
+		}
+	}
+
+	mean, variance := n.RunningMean, n.RunningVar
+	if n.training {
+		mean, variance = n.batchMoments(X)
+	}
+
+	n.invStd = make([]float64, n.Size)
+	for f := range n.invStd {
+		n.invStd[f] = 1 / math.Sqrt(variance[f]+n.Epsilon)
+	}
+
+	n.xhat = make([][]float64, len(X))
+	output := make([][]float64, len(X))
+	for idx, x := range X {
+		n.xhat[idx] = make([]float64, n.Size)
+		output[idx] = make([]float64, n.Size)
+		for f, el := range x {
+			n.xhat[idx][f] = (el - mean[f]) * n.invStd[f]
+			output[idx][f] = n.Gain[f]*n.xhat[idx][f] + n.Bias[f]
+		}
+	}
+	n.batchStats = n.training
+	n.singleInput = false
+
+	return output, nil
+}
+
+func (n *BatchNorm1d) BackwardBatch(dY [][]float64) ([][]float64, error) {
+	if len(dY) != len(n.xhat) || n.xhat == nil {
+		return nil, fmt.Errorf("dimension mismatch: dY has %d rows, last batch had %d", len(dY), len(n.xhat))
+	}
+	for idx, dy := range dY {
+		if len(dy) != n.Size {
+			return nil, fmt.Errorf("dimension mismatch: dY row %d has length %d, expected %d", idx, len(dy), n.Size)
+		}
+	}
+
+	n.ensureGrads()
+
+	batch := float64(len(dY))
+	dX := make([][]float64, len(dY))
+	for idx := range dX {
+		dX[idx] = make([]float64, n.Size)
+	}
+
+	for f := 0; f < n.Size; f++ {
+		sumDxhat, sumDxhatXhat := 0.0, 0.0
+		for idx, dy := range dY {
+			n.GradGain[f] += dy[f] * n.xhat[idx][f]
+			n.GradBias[f] += dy[f]
+			dxhat := dy[f] * n.Gain[f]
+			sumDxhat += dxhat
+			sumDxhatXhat += dxhat * n.xhat[idx][f]
+		}
+
+		for idx, dy := range dY {
+			dxhat := dy[f] * n.Gain[f]
+			if !n.batchStats {
+				// The running statistics are constants.
+				dX[idx][f] = dxhat * n.invStd[f]
+				continue
+			}
+			dX[idx][f] = n.invStd[f] / batch * (batch*dxhat - sumDxhat - n.xhat[idx][f]*sumDxhatXhat)
+		}
+	}
+
+	return dX, nil
+}
+
+func (n *BatchNorm1d) Params() []Param {
+	n.ensureGrads()
+
+	return []Param{
+		{Name: "gain", Kind: ParamNorm, Value: [][]float64{n.Gain}, Grad: [][]float64{n.GradGain}},
+		{Name: "bias", Kind: ParamBias, Value: [][]float64{n.Bias}, Grad: [][]float64{n.GradBias}},
+	}
+}
+
+// batchMoments returns the per-feature mean and biased variance of X and folds
+// them into the running statistics, using the unbiased variance there.
+func (n *BatchNorm1d) batchMoments(X [][]float64) ([]float64, []float64) {
+	batch := float64(len(X))
+	mean := make([]float64, n.Size)
+	variance := make([]float64, n.Size)
+
+	for _, x := range X {
+		for f, el := range x {
+			mean[f] += el / batch
+		}
+	}
+	for _, x := range X {
+		for f, el := range x {
+			variance[f] += (el - mean[f]) * (el - mean[f]) / batch
+		}
+	}
+
+	for f := range mean {
+		unbiased := variance[f] * batch / (batch - 1)
+		n.RunningMean[f] = (1-n.Momentum)*n.RunningMean[f] + n.Momentum*mean[f]
+		n.RunningVar[f] = (1-n.Momentum)*n.RunningVar[f] + n.Momentum*unbiased
+	}
+
+	return mean, variance
+}
+
+func (n *BatchNorm1d) ensureGrads() {
+	if len(n.GradGain) != len(n.Gain) {
+		n.GradGain = make([]float64, len(n.Gain))
+	}
+	if len(n.GradBias) != len(n.Bias) {
+		n.GradBias = make([]float64, len(n.Bias))
+	}
+}
@@ -0,0 +1,157 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchNorm1d(t *testing.T) {
+	X := [][]float64{{1, 10}, {2, 20}, {3, 60}, {6, 30}}
+
+	t.Run("training normalises each feature across the batch", func(t *testing.T) {
+		// Arrange
+		bn, err := NewBatchNorm1d(2)
+		assert.NoError(t, err)
+
+		// Act
+		Y, err := bn.ForwardBatch(X)
+
+		// Assert
+		assert.NoError(t, err)
+		for f := range 2 {
+			mean, variance := 0.0, 0.0
+			for _, y := range Y {
+				mean += y[f] / 4
+			}
+			for _, y := range Y {
+				variance += (y[f] - mean) * (y[f] - mean) / 4
+			}
+			assert.InDelta(t, 0.0, mean, 1e-12)
+			assert.InDelta(t, 1.0, variance, 1e-3)
+		}
+	})
+
+	t.Run("training updates running statistics with momentum", func(t *testing.T) {
+		// Arrange
+		bn, err := NewBatchNorm1d(2)
+		assert.NoError(t, err)
+
+		// Act
+		_, err = bn.ForwardBatch(X)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{0.1 * 3, 0.1 * 30}, bn.RunningMean, 1e-12)
+		// unbiased variance of feature 0 is 14/3
+		assert.InDelta(t, 0.9+0.1*14.0/3, bn.RunningVar[0], 1e-12)
+	})
+
+	t.Run("evaluation uses running statistics on single samples", func(t *testing.T) {
+		// Arrange
+		bn, err := NewBatchNorm1d(1)
+		assert.NoError(t, err)
+		bn.RunningMean = []float64{2}
+		bn.RunningVar = []float64{4}
+		bn.Epsilon = 1e-12
+		bn.SetTraining(false)
+
+		// Act
+		y, err := bn.Forward([]float64{6})
+		assert.NoError(t, err)
+		dx, err := bn.Backward([]float64{1})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{2}, y, 1e-9)
+		assert.InDeltaSlice(t, []float64{0.5}, dx, 1e-9)
+	})
+
+	t.Run("Forward is rejected in training mode", func(t *testing.T) {
+		// Arrange
+		bn, err := NewBatchNorm1d(2)
+		assert.NoError(t, err)
+
+		// Act
+		_, err = bn.Forward([]float64{1, 2})
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("training batch gradients match finite differences", func(t *testing.T) {
+		// Arrange
+		bn, err := NewBatchNorm1d(2)
+		assert.NoError(t, err)
+		bn.Gain = []float64{1.5, -0.7}
+		bn.Bias = []float64{0.2, 0.1}
+
+		// Act
+		report, err := gradCheckBatch(bn, X)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-5))
+	})
+
+	t.Run("Block runs BatchNorm1d between linear and nonlinearity", func(t *testing.T) {
+		// Arrange
+		bn, err := NewBatchNorm1d(3)
+		assert.NoError(t, err)
+		block := &Block{
+			LinearLayer:  LinearLayer{In: 2, Out: 3, W: [][]float64{{0.5, -0.3}, {0.2, 0.8}, {-0.6, 0.4}}, B: []float64{0.1, 0.2, 0.3}},
+			Norm:         bn,
+			Nonlinearity: ReLU{},
+		}
+		batch := [][]float64{{1.1, 0.7}, {-0.4, 0.9}, {0.3, -1.2}, {2.0, 0.1}}
+
+		// Act
+		report, err := gradCheckBatch(block, batch)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-5))
+
+		block.SetTraining(false)
+		_, err = block.Forward(batch[0])
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns errors for invalid batches", func(t *testing.T) {
+		// Arrange
+		bn, err := NewBatchNorm1d(2)
+		assert.NoError(t, err)
+
+		// Act
+		_, errSingle := bn.ForwardBatch([][]float64{{1, 2}})
+		_, errWidth := bn.ForwardBatch([][]float64{{1, 2}, {1}})
+		_, errBackward := bn.BackwardBatch([][]float64{{1, 2}})
+		_, errNew := NewBatchNorm1d(0)
+
+		// Assert
+		assert.Error(t, errSingle)
+		assert.Error(t, errWidth)
+		assert.Error(t, errBackward)
+		assert.Error(t, errNew)
+	})
+
+	t.Run("rejects NaN epsilon and momentum", func(t *testing.T) {
+		// Arrange
+		eps, err := NewBatchNorm1d(2)
+		assert.NoError(t, err)
+		eps.Epsilon = math.NaN()
+		momentum, err := NewBatchNorm1d(2)
+		assert.NoError(t, err)
+		momentum.Momentum = math.NaN()
+
+		// Act
+		_, errEps := eps.ForwardBatch([][]float64{{1, 2}, {3, 4}})
+		_, errMomentum := momentum.ForwardBatch([][]float64{{1, 2}, {3, 4}})
+
+		// Assert
+		assert.ErrorContains(t, errEps, "epsilon")
+		assert.ErrorContains(t, errMomentum, "momentum")
+		assert.Equal(t, []float64{0, 0}, momentum.RunningMean)
+	})
+}
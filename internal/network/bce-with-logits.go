@@ -0,0 +1,67 @@
+package network
+
+import (
+	"fmt"
+	"math"
+)
+
+// BCEWithLogits is the mean binary cross-entropy over independent labels,
+// computed directly from logits z (e.g. the output of MLP.Forward) so no
+// sigmoid or clamping is needed. Each element uses the stable form
+//
+//	max(z,0) - z*y + log(1+exp(-|z|))
+//
+// posWeight optionally scales the positive term of each label to counter
+// class imbalance; nil weighs every label 1. The returned gradient is with
+// respect to z.
+func BCEWithLogits(z, y, posWeight []float64) (float64, []float64, error) {
+	if len(z) == 0 {
+		return 0, nil, fmt.Errorf("z must have length")
+	}
+	if len(y) != len(z) {
+		return 0, nil, fmt.Errorf("z and y must have same length")
+	}
+	if posWeight != nil && len(posWeight) != len(z) {
+		return 0, nil, fmt.Errorf("posWeight length %d, expected %d", len(posWeight), len(z))
+	}
+
+	n := float64(len(z))
+	loss := 0.0
+	grad := make([]float64, len(z))
+
+	for i := range z {
+		if math.IsNaN(z[i]) || math.IsInf(z[i], 0) {
+			return 0, nil, fmt.Errorf("z[%d] is NaN/Inf", i)
+		}
+		if math.IsNaN(y[i]) || y[i] < 0 || y[i] > 1 {
+			return 0, nil, fmt.Errorf("y[%d] must be in [0, 1], got %v", i, y[i])
+		}
+
+		pw := 1.0
+		if posWeight != nil {
+			pw = posWeight[i]
+			if math.IsNaN(pw) || math.IsInf(pw, 0) || pw < 0 {
+				return 0, nil, fmt.Errorf("posWeight[%d]=%v must be finite and non-negative", i, pw)
+			}
+		}
+
+		// With a positive weight the loss is (1-y)*z + l*softplus(-z) where
+		// l = 1 + (pw-1)*y, which reduces to the form above when pw is 1.
+		l := 1 + (pw-1)*y[i]
+		softplusNeg := math.Max(-z[i], 0) + math.Log1p(math.Exp(-math.Abs(z[i])))
+
+		loss += (1-y[i])*z[i] + l*softplusNeg
+		grad[i] = ((1 - y[i]) - l*sigmoid(-z[i])) / n
+	}
+
+	return loss / n, grad, nil
+}
+
+// sigmoid is the logistic function, evaluated without overflow for large |x|.
+func sigmoid(x float64) float64 {
+	if x >= 0 {
+		return 1 / (1 + math.Exp(-x))
+	}
+	e := math.Exp(x)
+	return e / (1 + e)
+}
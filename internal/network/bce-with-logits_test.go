@@ -0,0 +1,119 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBCEWithLogits(t *testing.T) {
+	t.Run("agrees with BinaryCrossEntropy on sigmoid probabilities", func(t *testing.T) {
+		// Arrange
+		z := []float64{1.3, -0.7, 0.2}
+		y := []float64{1, 0, 1}
+		expected := 0.0
+		for i := range z {
+			l, err := BinaryCrossEntropy(y[i], 1/(1+math.Exp(-z[i])))
+			assert.NoError(t, err)
+			expected += l / 3
+		}
+
+		// Act
+		loss, _, err := BCEWithLogits(z, y, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, expected, loss, 1e-12)
+	})
+
+	t.Run("is finite and exact for extreme logits", func(t *testing.T) {
+		// Act
+		loss, grad, err := BCEWithLogits([]float64{-800, 800}, []float64{1, 0}, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 800.0, loss, 1e-9)
+		assert.InDeltaSlice(t, []float64{-0.5, 0.5}, grad, 1e-12)
+	})
+
+	t.Run("positive weights scale only the positive term", func(t *testing.T) {
+		// Arrange
+		z := []float64{0.4, 0.4}
+		y := []float64{1, 0}
+		base, _, err := BCEWithLogits(z[:1], y[:1], nil)
+		assert.NoError(t, err)
+		neg, _, err := BCEWithLogits(z[1:], y[1:], nil)
+		assert.NoError(t, err)
+
+		// Act
+		weighted, _, err := BCEWithLogits(z, y, []float64{3, 3})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, (3*base+neg)/2, weighted, 1e-12)
+	})
+
+	t.Run("gradient matches central differences", func(t *testing.T) {
+		// Arrange
+		z := []float64{0.9, -1.4, 0.05, 2.2}
+		y := []float64{1, 0, 0.3, 1}
+		pw := []float64{2, 1, 0.5, 4}
+		_, grad, err := BCEWithLogits(z, y, pw)
+		assert.NoError(t, err)
+		const h = 1e-6
+
+		for i := range z {
+			// Act
+			plus := append([]float64(nil), z...)
+			minus := append([]float64(nil), z...)
+			plus[i] += h
+			minus[i] -= h
+			lp, _, err1 := BCEWithLogits(plus, y, pw)
+			lm, _, err2 := BCEWithLogits(minus, y, pw)
+
+			// Assert
+			assert.NoError(t, err1)
+			assert.NoError(t, err2)
+			assert.InDelta(t, (lp-lm)/(2*h), grad[i], 1e-7)
+		}
+	})
+
+	t.Run("accepts MLP.Forward output", func(t *testing.T) {
+		// Arrange
+		mlp := MLP{
+			Hidden: Block{
+				LinearLayer:  LinearLayer{In: 2, Out: 2, W: [][]float64{{0.5, -0.2}, {0.1, 0.3}}},
+				Nonlinearity: ReLU{},
+			},
+			Out: LinearLayer{In: 2, Out: 3, W: [][]float64{{1, 0}, {0, 1}, {-1, 1}}},
+		}
+		z, err := mlp.Forward([]float64{1, 2})
+		assert.NoError(t, err)
+
+		// Act
+		_, grad, err := BCEWithLogits(z, []float64{1, 0, 1}, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, grad, 3)
+	})
+
+	t.Run("returns errors for invalid input", func(t *testing.T) {
+		// Act
+		_, _, errEmpty := BCEWithLogits([]float64{}, []float64{}, nil)
+		_, _, errLen := BCEWithLogits([]float64{1}, []float64{1, 0}, nil)
+		_, _, errLabel := BCEWithLogits([]float64{1}, []float64{2}, nil)
+		_, _, errNaN := BCEWithLogits([]float64{math.NaN()}, []float64{1}, nil)
+		_, _, errWeight := BCEWithLogits([]float64{1}, []float64{1}, []float64{-1})
+		_, _, errWeightLen := BCEWithLogits([]float64{1}, []float64{1}, []float64{1, 1})
+
+		// Assert
+		assert.Error(t, errEmpty)
+		assert.Error(t, errLen)
+		assert.Error(t, errLabel)
+		assert.Error(t, errNaN)
+		assert.Error(t, errWeight)
+		assert.Error(t, errWeightLen)
+	})
+}
@@ -20,30 +20,279 @@ func NewBlock(l LinearLayer, nl Nonlinearity) (*Block, error) {
 	}, nil
 }
 
+// Block is a LinearLayer followed by a Nonlinearity. Optional stages compose
+// around them in the order PreNorm, LinearLayer, Norm, Nonlinearity, Dropout.
 type Block struct {
-	LinearLayer  LinearLayer
+	// PreNorm is an optional normalisation applied to the block input.
+	PreNorm     Layer
+	LinearLayer LinearLayer
+	// Norm is an optional normalisation (e.g. LayerNorm or BatchNorm1d)
+	// applied between the linear layer and the nonlinearity.
+	Norm         Layer
 	Nonlinearity Nonlinearity
+	// Dropout is optionally applied to the activation.
+	Dropout *Dropout
+
+	// y and ys are the nonlinearity inputs of the last Forward and
+	// ForwardBatch, kept for the backward passes. pre is the nonlinearity
+	// input of the sample whose hooks are running.
+	y     []float64
+	ys    [][]float64
+	pre   []float64
+	hooks forwardHooks
 }
 
-func (b Block) Forward(x []float64) ([]float64, error) {
+func (b *Block) Forward(x []float64) ([]float64, error) {
 	if err := b.Validate(); err != nil {
 		return nil, err
 	}
 
-	y, err := b.LinearLayer.Forward(x)
+	h := x
+	if b.PreNorm != nil {
+		var err error
+		h, err = b.PreNorm.Forward(h)
+		if err != nil {
+			return nil, errors.Join(errors.New("unable to pre-normalise block"), err)
+		}
+	}
+
+	y, err := b.LinearLayer.Forward(h)
 	if err != nil {
 		return nil, errors.Join(errors.New("unable to create block"), err)
 	}
 
+	if b.Norm != nil {
+		y, err = b.Norm.Forward(y)
+		if err != nil {
+			return nil, errors.Join(errors.New("unable to normalise block"), err)
+		}
+	}
+
 	a, err := b.Nonlinearity.Apply(y)
 	if err != nil {
 		return nil, errors.Join(errors.New("unable to apply nonlinearity to block"), err)
 	}
 
-	return a, nil
+	b.y = y
+
+	out := a
+	if b.Dropout != nil {
+		out, err = b.Dropout.Forward(a)
+		if err != nil {
+			return nil, errors.Join(errors.New("unable to apply dropout to block"), err)
+		}
+	}
+	b.runHooks(x, y, a)
+
+	return out, nil
+}
+
+// Backward propagates dy through each stage of the block in reverse.
+func (b *Block) Backward(dy []float64) ([]float64, error) {
+	if b.y == nil {
+		return nil, fmt.Errorf("Block Backward called before Forward")
+	}
+
+	if b.Dropout != nil {
+		var err error
+		dy, err = b.Dropout.Backward(dy)
+		if err != nil {
+			return nil, errors.Join(errors.New("block unable to backward dropout"), err)
+		}
+	}
+
+	dz, err := b.Nonlinearity.Backward(b.y, dy)
+	if err != nil {
+		return nil, errors.Join(errors.New("block unable to backward nonlinearity"), err)
+	}
+
+	if b.Norm != nil {
+		dz, err = b.Norm.Backward(dz)
+		if err != nil {
+			return nil, errors.Join(errors.New("block unable to backward norm"), err)
+		}
+	}
+
+	dx, err := b.LinearLayer.Backward(dz)
+	if err != nil {
+		return nil, errors.Join(errors.New("block unable to backward linear layer"), err)
+	}
+
+	if b.PreNorm != nil {
+		dx, err = b.PreNorm.Backward(dx)
+		if err != nil {
+			return nil, errors.Join(errors.New("block unable to backward pre-norm"), err)
+		}
+	}
+
+	return dx, nil
+}
+
+// ForwardBatch runs the block over a batch, which a batch-dependent norm such
+// as BatchNorm1d needs while training. PreNorm and Norm must implement
+// BatchLayer.
+func (b *Block) ForwardBatch(X [][]float64) ([][]float64, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	H := X
+	if b.PreNorm != nil {
+		norm, err := asBatchLayer(b.PreNorm)
+		if err != nil {
+			return nil, err
+		}
+		H, err = norm.ForwardBatch(H)
+		if err != nil {
+			return nil, errors.Join(errors.New("unable to pre-normalise block batch"), err)
+		}
+	}
+
+	Y, err := b.LinearLayer.ForwardBatch(H)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create block batch"), err)
+	}
+
+	if b.Norm != nil {
+		norm, err := asBatchLayer(b.Norm)
+		if err != nil {
+			return nil, err
+		}
+		Y, err = norm.ForwardBatch(Y)
+		if err != nil {
+			return nil, errors.Join(errors.New("unable to normalise block batch"), err)
+		}
+	}
+
+	A := make([][]float64, len(Y))
+	for idx, y := range Y {
+		A[idx], err = b.Nonlinearity.Apply(y)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("unable to apply nonlinearity to block batch row %d", idx), err)
+		}
+	}
+	b.ys = Y
+
+	out := A
+	if b.Dropout != nil {
+		out, err = b.Dropout.ForwardBatch(A)
+		if err != nil {
+			return nil, errors.Join(errors.New("unable to apply dropout to block batch"), err)
+		}
+	}
+	for idx := range A {
+		b.runHooks(X[idx], Y[idx], A[idx])
+	}
+
+	return out, nil
+}
+
+func (b *Block) BackwardBatch(dY [][]float64) ([][]float64, error) {
+	if len(dY) != len(b.ys) {
+		return nil, fmt.Errorf("dimension mismatch: dY has %d rows, last batch had %d", len(dY), len(b.ys))
+	}
+
+	if b.Dropout != nil {
+		var err error
+		dY, err = b.Dropout.BackwardBatch(dY)
+		if err != nil {
+			return nil, errors.Join(errors.New("block unable to backward dropout batch"), err)
+		}
+	}
+
+	dZ := make([][]float64, len(dY))
+	for idx, dy := range dY {
+		var err error
+		dZ[idx], err = b.Nonlinearity.Backward(b.ys[idx], dy)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("block unable to backward nonlinearity on row %d", idx), err)
+		}
+	}
+
+	if b.Norm != nil {
+		norm, err := asBatchLayer(b.Norm)
+		if err != nil {
+			return nil, err
+		}
+		dZ, err = norm.BackwardBatch(dZ)
+		if err != nil {
+			return nil, errors.Join(errors.New("block unable to backward norm batch"), err)
+		}
+	}
+
+	dX, err := b.LinearLayer.BackwardBatch(dZ)
+	if err != nil {
+		return nil, errors.Join(errors.New("block unable to backward linear layer batch"), err)
+	}
+
+	if b.PreNorm != nil {
+		norm, err := asBatchLayer(b.PreNorm)
+		if err != nil {
+			return nil, err
+		}
+		dX, err = norm.BackwardBatch(dX)
+		if err != nil {
+			return nil, errors.Join(errors.New("block unable to backward pre-norm batch"), err)
+		}
+	}
+
+	return dX, nil
+}
+
+func (b *Block) Params() []Param {
+	params := make([]Param, 0)
+	if b.PreNorm != nil {
+		params = append(params, prefixParams("prenorm", b.PreNorm.Params())...)
+	}
+	params = append(params, prefixParams("linear", b.LinearLayer.Params())...)
+	if b.Norm != nil {
+		params = append(params, prefixParams("norm", b.Norm.Params())...)
+	}
+
+	return params
+}
+
+// SetTraining switches every stage of the block that has a training mode.
+func (b *Block) SetTraining(training bool) {
+	for _, l := range []Layer{b.PreNorm, b.Norm} {
+		if m, ok := l.(TrainingMode); ok {
+			m.SetTraining(training)
+		}
+	}
+	if b.Dropout != nil {
+		b.Dropout.SetTraining(training)
+	}
+}
+
+// Training reports whether any stage of the block is in training mode.
+func (b *Block) Training() bool {
+	if b.Dropout != nil && b.Dropout.Training() {
+		return true
+	}
+
+	return anyTraining(b.PreNorm, b.Norm)
 }
 
-func (b Block) Validate() error {
+// RegisterForwardHook adds h to the hooks run after every successful Forward,
+// and per row after ForwardBatch, receiving the block input and its
+// activation before dropout.
+func (b *Block) RegisterForwardHook(h ForwardHook) {
+	b.hooks = append(b.hooks, h)
+}
+
+func (b *Block) ClearForwardHooks() {
+	b.hooks = nil
+}
+
+// runHooks runs the hooks for one sample that made it through the whole
+// block, exposing its nonlinearity input y while they run.
+func (b *Block) runHooks(x, y, a []float64) {
+	b.pre = y
+	b.hooks.run(x, a)
+	b.pre = nil
+}
+
+func (b *Block) Validate() error {
 	if err := b.LinearLayer.Validate(); err != nil {
 		return errors.Join(errors.New("block validation failed on linear layer"), err)
 	}
@@ -54,3 +303,12 @@ func (b Block) Validate() error {
 
 	return nil
 }
+
+func asBatchLayer(l Layer) (BatchLayer, error) {
+	batch, ok := l.(BatchLayer)
+	if !ok {
+		return nil, fmt.Errorf("layer %T does not support batches", l)
+	}
+
+	return batch, nil
+}
@@ -61,3 +61,108 @@ func TestBlock(t *testing.T) {
 	})
 
 }
+
+func TestBlockBackward(t *testing.T) {
+	t.Run("Backward gradients match finite differences", func(t *testing.T) {
+		// Arrange
+		block := &Block{
+			LinearLayer: LinearLayer{
+				In:  2,
+				Out: 3,
+				W:   [][]float64{{4.3, -2.1}, {-9.8, 8.8}, {0.7, 1.9}},
+				B:   []float64{0.4, 2.2, -0.3},
+			},
+			Nonlinearity: ReLU{},
+		}
+
+		// Act
+		report, err := GradCheck(block, []float64{0.6, -0.4}, GradCheckConfig{Seed: 7})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-6))
+	})
+
+	t.Run("Backward fails before Forward", func(t *testing.T) {
+		// Arrange
+		block := Block{
+			LinearLayer:  LinearLayer{In: 1, Out: 1, W: [][]float64{{1}}},
+			Nonlinearity: ReLU{},
+		}
+
+		// Act
+		_, err := block.Backward([]float64{1})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestBlockComposition(t *testing.T) {
+	newBlock := func(t *testing.T) *Block {
+		pre, err := NewLayerNorm(2)
+		assert.NoError(t, err)
+		d, err := NewDropout(0.5, 11)
+		assert.NoError(t, err)
+		return &Block{
+			PreNorm:      pre,
+			LinearLayer:  LinearLayer{In: 2, Out: 3, W: [][]float64{{0.5, -0.3}, {0.2, 0.8}, {-0.6, 0.4}}, B: []float64{0.1, 0.2, 0.3}},
+			Nonlinearity: ReLU{},
+			Dropout:      d,
+		}
+	}
+
+	t.Run("pre-norm and dropout compose in evaluation", func(t *testing.T) {
+		// Arrange
+		block := newBlock(t)
+		block.SetTraining(false)
+
+		// Act
+		report, err := GradCheck(block, []float64{1.1, 0.7}, GradCheckConfig{Seed: 2})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-6))
+		assert.Equal(t, "prenorm.gain", block.Params()[0].Name)
+	})
+
+	t.Run("dropout zeroes the gradient of dropped units while training", func(t *testing.T) {
+		// Arrange
+		block := newBlock(t)
+		block.PreNorm = nil
+		x := []float64{1.1, 0.7}
+		y, err := block.Forward(x)
+		assert.NoError(t, err)
+
+		// Act
+		_, err = block.Backward([]float64{1, 1, 1})
+
+		// Assert
+		assert.NoError(t, err)
+		for o, v := range y {
+			if v == 0 {
+				assert.Equal(t, []float64{0, 0}, block.LinearLayer.GradW[o])
+			}
+		}
+	})
+
+	t.Run("SetTraining reaches every stage", func(t *testing.T) {
+		// Arrange
+		block := newBlock(t)
+		bn, err := NewBatchNorm1d(3)
+		assert.NoError(t, err)
+		block.Norm = bn
+
+		// Act
+		block.SetTraining(false)
+
+		// Assert
+		assert.False(t, block.Dropout.Training())
+		assert.False(t, block.Training())
+		_, err = bn.Forward([]float64{1, 2, 3})
+		assert.NoError(t, err)
+		block.SetTraining(true)
+		assert.True(t, bn.Training())
+		assert.True(t, block.Training())
+	})
+}
@@ -0,0 +1,67 @@
+package network
+
+import (
+	"fmt"
+	"math"
+)
+
+// ClipGradValue clamps every gradient element into [-clip, clip] in place.
+// Call it between Backward and the optimizer step.
+func ClipGradValue(params []Param, clip float64) error {
+	if clip <= 0 || math.IsNaN(clip) {
+		return fmt.Errorf("clip must be positive, got %v", clip)
+	}
+
+	for _, p := range params {
+		for _, row := range p.Grad {
+			for i, g := range row {
+				row[i] = max(-clip, min(clip, g))
+			}
+		}
+	}
+
+	return nil
+}
+
+// GradNorm is the global L2 norm of every gradient element across params.
+func GradNorm(params []Param) float64 {
+	sumSq := 0.0
+	for _, p := range params {
+		for _, row := range p.Grad {
+			for _, g := range row {
+				sumSq += g * g
+			}
+		}
+	}
+
+	return math.Sqrt(sumSq)
+}
+
+// ClipGradNorm rescales all gradients together so their global L2 norm is at
+// most maxNorm, preserving their direction. It returns the norm before
+// clipping so it can be logged. A non-finite norm is returned as an error
+// rather than propagated into the gradients.
+func ClipGradNorm(params []Param, maxNorm float64) (float64, error) {
+	if maxNorm <= 0 || math.IsNaN(maxNorm) {
+		return 0, fmt.Errorf("maxNorm must be positive, got %v", maxNorm)
+	}
+
+	norm := GradNorm(params)
+	if math.IsNaN(norm) || math.IsInf(norm, 0) {
+		return norm, fmt.Errorf("gradient norm is not finite: %v", norm)
+	}
+	if norm <= maxNorm {
+		return norm, nil
+	}
+
+	scale := maxNorm / norm
+	for _, p := range params {
+		for _, row := range p.Grad {
+			for i := range row {
+				row[i] *= scale
+			}
+		}
+	}
+
+	return norm, nil
+}
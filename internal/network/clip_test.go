@@ -0,0 +1,100 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func clipParams() []Param {
+	return []Param{
+		{Name: "W", Value: [][]float64{{0, 0}, {0, 0}}, Grad: [][]float64{{3, -4}, {0, 12}}},
+		{Name: "B", Value: [][]float64{{0}}, Grad: [][]float64{{0}}},
+	}
+}
+
+func TestClipGradValue(t *testing.T) {
+	t.Run("clamps each element", func(t *testing.T) {
+		// Arrange
+		params := clipParams()
+
+		// Act
+		err := ClipGradValue(params, 3.5)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{3, -3.5}, {0, 3.5}}, params[0].Grad)
+	})
+
+	t.Run("returns an error for a non-positive clip", func(t *testing.T) {
+		// Act
+		err := ClipGradValue(clipParams(), 0)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestClipGradNorm(t *testing.T) {
+	t.Run("rescales to maxNorm and returns the pre-clip norm", func(t *testing.T) {
+		// Arrange
+		params := clipParams()
+
+		// Act
+		norm, err := ClipGradNorm(params, 6.5)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 13.0, norm, 1e-12)
+		assert.InDelta(t, 6.5, GradNorm(params), 1e-12)
+		assert.InDeltaSlice(t, []float64{1.5, -2}, params[0].Grad[0], 1e-12)
+	})
+
+	t.Run("leaves gradients under the limit untouched", func(t *testing.T) {
+		// Arrange
+		params := clipParams()
+
+		// Act
+		norm, err := ClipGradNorm(params, 100)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 13.0, norm, 1e-12)
+		assert.Equal(t, [][]float64{{3, -4}, {0, 12}}, params[0].Grad)
+	})
+
+	t.Run("clips across every param of an MLP", func(t *testing.T) {
+		// Arrange
+		mlp := &MLP{
+			Hidden: Block{
+				LinearLayer:  LinearLayer{In: 2, Out: 2, W: [][]float64{{5, 1}, {2, 3}}, B: []float64{1, 1}},
+				Nonlinearity: ReLU{},
+			},
+			Out: LinearLayer{In: 2, Out: 2, W: [][]float64{{4, 1}, {1, 4}}, B: []float64{0, 0}},
+		}
+		_, err := mlp.Forward([]float64{10, 20})
+		assert.NoError(t, err)
+		_, err = mlp.Backward([]float64{10, -30})
+		assert.NoError(t, err)
+
+		// Act
+		norm, err := ClipGradNorm(mlp.Params(), 1)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Greater(t, norm, 1.0)
+		assert.InDelta(t, 1.0, GradNorm(mlp.Params()), 1e-12)
+	})
+
+	t.Run("returns an error for a non-finite norm", func(t *testing.T) {
+		// Arrange
+		params := []Param{{Name: "W", Value: [][]float64{{0}}, Grad: [][]float64{{math.Inf(1)}}}}
+
+		// Act
+		_, err := ClipGradNorm(params, 1)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
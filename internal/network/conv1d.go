@@ -0,0 +1,157 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// Conv1DConfig describes a 1-D convolution over a sequence of In-dimensional
+// rows. Stride and Dilation default to 1.
+type Conv1DConfig struct {
+	In       int
+	Out      int
+	Kernel   int
+	Stride   int
+	Dilation int
+	// Causal left-pads the sequence with (Kernel-1)·Dilation zero rows so
+	// output t only sees inputs at or before t·Stride. Without it the
+	// convolution is unpadded ("valid").
+	Causal bool
+}
+
+// Conv1D applies the same LinearLayer to every window of Kernel rows spaced
+// Dilation apart, moving Stride rows at a time. The window is flattened row
+// by row, so LinearLayer.W[o][k*In+i] weights input channel i at tap k.
+type Conv1D struct {
+	Conv1DConfig
+	LinearLayer LinearLayer
+
+	windows [][]float64
+	length  int
+}
+
+func NewConv1D(cfg Conv1DConfig, rng *rand.Rand) (*Conv1D, error) {
+	if cfg.Stride == 0 {
+		cfg.Stride = 1
+	}
+	if cfg.Dilation == 0 {
+		cfg.Dilation = 1
+	}
+	if cfg.Kernel <= 0 || cfg.Stride < 0 || cfg.Dilation < 0 {
+		return nil, fmt.Errorf("invalid conv dims: Kernel=%d Stride=%d Dilation=%d", cfg.Kernel, cfg.Stride, cfg.Dilation)
+	}
+
+	l, err := NewLinearLayer(cfg.Kernel*cfg.In, cfg.Out, true, HeNormal, rng)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create conv kernel"), err)
+	}
+
+	return &Conv1D{Conv1DConfig: cfg, LinearLayer: *l}, nil
+}
+
+func (c *Conv1D) Validate() error {
+	if c.Kernel <= 0 || c.Stride <= 0 || c.Dilation <= 0 {
+		return fmt.Errorf("invalid conv dims: Kernel=%d Stride=%d Dilation=%d", c.Kernel, c.Stride, c.Dilation)
+	}
+	if err := c.LinearLayer.Validate(); err != nil {
+		return errors.Join(errors.New("conv failed to validate kernel"), err)
+	}
+	if c.LinearLayer.In != c.Kernel*c.In || c.LinearLayer.Out != c.Out {
+		return fmt.Errorf("dimension mismatch: kernel layer is %dx%d, expected %dx%d", c.LinearLayer.Out, c.LinearLayer.In, c.Out, c.Kernel*c.In)
+	}
+
+	return nil
+}
+
+// OutputLength is the number of rows produced for an input of length rows,
+// or 0 if the input is shorter than the receptive field.
+func (c *Conv1D) OutputLength(length int) int {
+	span := c.Dilation*(c.Kernel-1) + 1
+	padded := length + c.padding()
+	if padded < span {
+		return 0
+	}
+
+	return (padded-span)/c.Stride + 1
+}
+
+func (c *Conv1D) padding() int {
+	if !c.Causal {
+		return 0
+	}
+
+	return c.Dilation * (c.Kernel - 1)
+}
+
+// ForwardSequence convolves the rows of X.
+func (c *Conv1D) ForwardSequence(X [][]float64) ([][]float64, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	for t, x := range X {
+		if len(x) != c.In {
+			return nil, fmt.Errorf("dimension mismatch: row %d has length %d, expected %d", t, len(x), c.In)
+		}
+	}
+
+	steps := c.OutputLength(len(X))
+	if steps == 0 {
+		return nil, fmt.Errorf("sequence of length %d is shorter than the receptive field", len(X))
+	}
+
+	pad := c.padding()
+	windows := make([][]float64, steps)
+	for t := range windows {
+		window := make([]float64, 0, c.Kernel*c.In)
+		for k := range c.Kernel {
+			row := t*c.Stride + k*c.Dilation - pad
+			if row < 0 {
+				window = append(window, make([]float64, c.In)...)
+				continue
+			}
+			window = append(window, X[row]...)
+		}
+		windows[t] = window
+	}
+
+	output, err := c.LinearLayer.ForwardBatch(windows)
+	if err != nil {
+		return nil, errors.Join(errors.New("conv unable to apply kernel"), err)
+	}
+
+	c.windows = windows
+	c.length = len(X)
+
+	return output, nil
+}
+
+// BackwardSequence accumulates kernel gradients and returns the gradient with
+// respect to the rows of the last ForwardSequence input.
+func (c *Conv1D) BackwardSequence(dY [][]float64) ([][]float64, error) {
+	if len(dY) != len(c.windows) {
+		return nil, fmt.Errorf("dimension mismatch: dY has %d rows, last forward produced %d", len(dY), len(c.windows))
+	}
+
+	pad := c.padding()
+	dX := zeroRows(c.length, c.In)
+	for t, dy := range dY {
+		dWindow, err := c.LinearLayer.backward(c.windows[t], dy)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("conv backward failed at step %d", t), err)
+		}
+		for k := range c.Kernel {
+			row := t*c.Stride + k*c.Dilation - pad
+			if row < 0 {
+				continue
+			}
+			addInto(dX[row], dWindow[k*c.In:(k+1)*c.In])
+		}
+	}
+
+	return dX, nil
+}
+
+func (c *Conv1D) Params() []Param {
+	return c.LinearLayer.Params()
+}
@@ -0,0 +1,130 @@
+package network
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConv1D(t *testing.T) {
+	t.Run("computes a known dilated convolution", func(t *testing.T) {
+		// Arrange
+		c := &Conv1D{
+			Conv1DConfig: Conv1DConfig{In: 1, Out: 1, Kernel: 2, Stride: 1, Dilation: 2},
+			LinearLayer:  LinearLayer{In: 2, Out: 1, W: [][]float64{{1, 10}}, B: []float64{0.5}},
+		}
+
+		// Act
+		Y, err := c.ForwardSequence([][]float64{{1}, {2}, {3}, {4}})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1 + 30 + 0.5}, {2 + 40 + 0.5}}, Y)
+	})
+
+	t.Run("output lengths follow stride, dilation and padding", func(t *testing.T) {
+		for _, tc := range []struct {
+			cfg  Conv1DConfig
+			want int
+		}{
+			{Conv1DConfig{Kernel: 3, Stride: 1, Dilation: 1}, 8},
+			{Conv1DConfig{Kernel: 3, Stride: 2, Dilation: 1}, 4},
+			{Conv1DConfig{Kernel: 2, Stride: 1, Dilation: 4}, 6},
+			{Conv1DConfig{Kernel: 2, Stride: 1, Dilation: 4, Causal: true}, 10},
+			{Conv1DConfig{Kernel: 2, Stride: 2, Dilation: 1, Causal: true}, 5},
+		} {
+			// Act
+			got := (&Conv1D{Conv1DConfig: tc.cfg}).OutputLength(10)
+
+			// Assert
+			assert.Equal(t, tc.want, got, "%+v", tc.cfg)
+		}
+	})
+
+	t.Run("causal outputs never see future inputs", func(t *testing.T) {
+		// Arrange
+		c, err := NewConv1D(Conv1DConfig{In: 2, Out: 3, Kernel: 2, Dilation: 2, Causal: true}, rand.New(rand.NewPCG(2, 2)))
+		assert.NoError(t, err)
+		X := [][]float64{{1, 0}, {0, 1}, {2, -1}, {0.5, 0.5}}
+		changed := cloneMatrix(X)
+		changed[3] = []float64{9, 9}
+
+		// Act
+		Y1, err1 := c.ForwardSequence(X)
+		Y2, err2 := c.ForwardSequence(changed)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Len(t, Y1, 4)
+		assert.Equal(t, Y1[:3], Y2[:3])
+	})
+
+	t.Run("a WaveNet-style stack matches finite differences", func(t *testing.T) {
+		// Arrange
+		rng := rand.New(rand.NewPCG(6, 6))
+		first, err := NewConv1D(Conv1DConfig{In: 2, Out: 3, Kernel: 2, Dilation: 1, Causal: true}, rng)
+		assert.NoError(t, err)
+		second, err := NewConv1D(Conv1DConfig{In: 3, Out: 2, Kernel: 2, Stride: 2, Dilation: 2, Causal: true}, rng)
+		assert.NoError(t, err)
+		X := [][]float64{{0.4, -1.1}, {0.9, 0.2}, {-0.3, 0.7}, {1.2, -0.5}, {0.1, 0.6}}
+		dX := zeroRows(len(X), 2)
+
+		forward := func() ([][]float64, error) {
+			H, err := first.ForwardSequence(X)
+			if err != nil {
+				return nil, err
+			}
+			return second.ForwardSequence(H)
+		}
+		loss := func() (float64, error) {
+			Y, err := forward()
+			total := 0.0
+			for t := range Y {
+				total += float64(t+1) * (Y[t][0] - 2*Y[t][1])
+			}
+			return total, err
+		}
+		backward := func() error {
+			Y, err := forward()
+			if err != nil {
+				return err
+			}
+			dY := make([][]float64, len(Y))
+			for t := range dY {
+				dY[t] = []float64{float64(t + 1), -2 * float64(t+1)}
+			}
+			dH, err := second.BackwardSequence(dY)
+			if err != nil {
+				return err
+			}
+			grad, err := first.BackwardSequence(dH)
+			for t := range grad {
+				copy(dX[t], grad[t])
+			}
+			return err
+		}
+		params := append(prefixParams("first", first.Params()), prefixParams("second", second.Params())...)
+		params = append(params, Param{Name: "input", Value: X, Grad: dX})
+
+		// Act
+		report, err := GradCheckLoss(params, loss, backward, GradCheckConfig{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-5))
+	})
+
+	t.Run("rejects sequences shorter than the receptive field", func(t *testing.T) {
+		// Arrange
+		c, err := NewConv1D(Conv1DConfig{In: 1, Out: 1, Kernel: 3, Dilation: 2}, rand.New(rand.NewPCG(1, 1)))
+		assert.NoError(t, err)
+
+		// Act
+		_, err = c.ForwardSequence([][]float64{{1}, {2}, {3}, {4}})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,152 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Reduction controls how per-sample losses are combined into a single loss.
+type Reduction int
+
+const (
+	// ReductionMean averages the per-sample losses. When class weights are
+	// given the average is weighted by the weight of each sample's target.
+	ReductionMean Reduction = iota
+	// ReductionSum adds the per-sample losses.
+	ReductionSum
+	// ReductionNone leaves the loss unreduced; only PerSample is populated.
+	ReductionNone
+)
+
+// CrossEntropyOptions configures BatchCrossEntropy. The zero value is an
+// unweighted mean over every sample without smoothing.
+type CrossEntropyOptions struct {
+	Reduction Reduction
+	// Weights holds one weight per class, used to rebalance imbalanced data.
+	// A nil slice weighs every class equally.
+	Weights []float64
+	// LabelSmoothing moves this much probability mass from the target class
+	// onto a uniform distribution over all classes. Must be in [0, 1).
+	LabelSmoothing float64
+	// IgnoreIndex, when set, marks a target value (e.g. a padding token) whose
+	// samples contribute neither loss nor gradient.
+	IgnoreIndex *int
+}
+
+// BatchLoss is the result of a batched loss computation.
+type BatchLoss struct {
+	// Loss is the reduced loss. It is 0 for ReductionNone.
+	Loss float64
+	// PerSample holds each sample's (weighted, smoothed) loss. Ignored samples
+	// are 0.
+	PerSample []float64
+	// Grad is the gradient of Loss with respect to the logits. For
+	// ReductionNone row i is the gradient of PerSample[i].
+	Grad [][]float64
+}
+
+// BatchCrossEntropy computes the cross-entropy between a batch of logits and
+// integer class targets. The log-probabilities come from LogSoftmax so no
+// clamping is required.
+func BatchCrossEntropy(logits [][]float64, targets []int, opts CrossEntropyOptions) (BatchLoss, error) {
+	if len(logits) == 0 {
+		return BatchLoss{}, fmt.Errorf("logits must have at least one row")
+	}
+	if len(targets) != len(logits) {
+		return BatchLoss{}, fmt.Errorf("dimension mismatch: %d targets for %d rows of logits", len(targets), len(logits))
+	}
+	if opts.LabelSmoothing < 0 || opts.LabelSmoothing >= 1 || math.IsNaN(opts.LabelSmoothing) {
+		return BatchLoss{}, fmt.Errorf("label smoothing must be in [0, 1), got %v", opts.LabelSmoothing)
+	}
+
+	classes := len(logits[0])
+	if classes == 0 {
+		return BatchLoss{}, fmt.Errorf("logits must have at least one class")
+	}
+
+	weights := opts.Weights
+	if weights == nil {
+		weights = make([]float64, classes)
+		for c := range weights {
+			weights[c] = 1
+		}
+	}
+	if len(weights) != classes {
+		return BatchLoss{}, fmt.Errorf("dimension mismatch: %d class weights for %d classes", len(weights), classes)
+	}
+	for c, w := range weights {
+		if math.IsNaN(w) || math.IsInf(w, 0) || w < 0 {
+			return BatchLoss{}, fmt.Errorf("weights[%d]=%v must be finite and non-negative", c, w)
+		}
+	}
+
+	eps := opts.LabelSmoothing
+	result := BatchLoss{
+		PerSample: make([]float64, len(logits)),
+		Grad:      make([][]float64, len(logits)),
+	}
+	total, norm := 0.0, 0.0
+
+	for i, z := range logits {
+		result.Grad[i] = make([]float64, classes)
+
+		if len(z) != classes {
+			return BatchLoss{}, fmt.Errorf("shape is not rectangular: row %d has length %d, expected %d", i, len(z), classes)
+		}
+		target := targets[i]
+		if opts.IgnoreIndex != nil && target == *opts.IgnoreIndex {
+			continue
+		}
+		if target < 0 || target >= classes {
+			return BatchLoss{}, fmt.Errorf("targets[%d]=%d out of range for %d classes", i, target, classes)
+		}
+
+		logP, err := LogSoftmax(z)
+		if err != nil {
+			return BatchLoss{}, errors.Join(fmt.Errorf("batch cross entropy failed on row %d", i), err)
+		}
+
+		// The sample loss is -sum_c a_c * logP_c where a_c is the weighted,
+		// smoothed target distribution.
+		coef := make([]float64, classes)
+		sumCoef := 0.0
+		for c := range coef {
+			coef[c] = eps / float64(classes) * weights[c]
+			if c == target {
+				coef[c] += (1 - eps) * weights[c]
+			}
+			sumCoef += coef[c]
+		}
+
+		loss := 0.0
+		for c := range coef {
+			loss -= coef[c] * logP[c]
+			result.Grad[i][c] = math.Exp(logP[c])*sumCoef - coef[c]
+		}
+
+		result.PerSample[i] = loss
+		total += loss
+		norm += weights[target]
+	}
+
+	switch opts.Reduction {
+	case ReductionMean:
+		if norm == 0 {
+			return BatchLoss{}, fmt.Errorf("mean reduction undefined: every sample is ignored or has zero weight")
+		}
+		result.Loss = total / norm
+		for _, row := range result.Grad {
+			for c := range row {
+				row[c] /= norm
+			}
+		}
+	case ReductionSum:
+		result.Loss = total
+	case ReductionNone:
+	default:
+		return BatchLoss{}, fmt.Errorf("unknown reduction %d", opts.Reduction)
+	}
+
+	return result, nil
+}
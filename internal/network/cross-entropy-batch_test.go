@@ -0,0 +1,162 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchCrossEntropy(t *testing.T) {
+	logits := [][]float64{
+		{2.0, 0.5, -1.0},
+		{0.1, 0.2, 0.3},
+		{-0.5, 1.5, 0.0},
+	}
+	targets := []int{0, 2, 1}
+
+	nll := func(z []float64, target int) float64 {
+		logP, err := LogSoftmax(z)
+		assert.NoError(t, err)
+		return -logP[target]
+	}
+
+	t.Run("mean reduction averages the per-sample NLL", func(t *testing.T) {
+		// Arrange
+		expected := (nll(logits[0], 0) + nll(logits[1], 2) + nll(logits[2], 1)) / 3
+
+		// Act
+		res, err := BatchCrossEntropy(logits, targets, CrossEntropyOptions{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, expected, res.Loss, 1e-12)
+		assert.InDelta(t, nll(logits[1], 2), res.PerSample[1], 1e-12)
+	})
+
+	t.Run("sum and none reductions", func(t *testing.T) {
+		// Act
+		sum, err1 := BatchCrossEntropy(logits, targets, CrossEntropyOptions{Reduction: ReductionSum})
+		none, err2 := BatchCrossEntropy(logits, targets, CrossEntropyOptions{Reduction: ReductionNone})
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.InDelta(t, none.PerSample[0]+none.PerSample[1]+none.PerSample[2], sum.Loss, 1e-12)
+		assert.Equal(t, 0.0, none.Loss)
+	})
+
+	t.Run("class weights scale samples and normalise the mean", func(t *testing.T) {
+		// Arrange
+		weights := []float64{2.0, 1.0, 0.5}
+		expected := (2.0*nll(logits[0], 0) + 0.5*nll(logits[1], 2) + 1.0*nll(logits[2], 1)) / 3.5
+
+		// Act
+		res, err := BatchCrossEntropy(logits, targets, CrossEntropyOptions{Weights: weights})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, expected, res.Loss, 1e-12)
+		assert.InDelta(t, 2.0*nll(logits[0], 0), res.PerSample[0], 1e-12)
+	})
+
+	t.Run("label smoothing mixes in the uniform distribution", func(t *testing.T) {
+		// Arrange
+		eps := 0.1
+		logP, err := LogSoftmax(logits[0])
+		assert.NoError(t, err)
+		expected := -(1-eps)*logP[0] - eps/3*(logP[0]+logP[1]+logP[2])
+
+		// Act
+		res, err := BatchCrossEntropy(logits[:1], targets[:1], CrossEntropyOptions{LabelSmoothing: eps})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, expected, res.Loss, 1e-12)
+	})
+
+	t.Run("ignore index drops samples from loss, gradient and mean", func(t *testing.T) {
+		// Arrange
+		pad := 2
+		expected := (nll(logits[0], 0) + nll(logits[2], 1)) / 2
+
+		// Act
+		res, err := BatchCrossEntropy(logits, targets, CrossEntropyOptions{IgnoreIndex: &pad})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, expected, res.Loss, 1e-12)
+		assert.Equal(t, 0.0, res.PerSample[1])
+		assert.InDeltaSlice(t, []float64{0, 0, 0}, res.Grad[1], 1e-12)
+	})
+
+	t.Run("gradient matches central differences", func(t *testing.T) {
+		// Arrange
+		opts := CrossEntropyOptions{Weights: []float64{1.5, 0.7, 1.0}, LabelSmoothing: 0.2}
+		res, err := BatchCrossEntropy(logits, targets, opts)
+		assert.NoError(t, err)
+		const h = 1e-6
+
+		for i := range logits {
+			for c := range logits[i] {
+				// Act
+				plus := cloneMatrix(logits)
+				minus := cloneMatrix(logits)
+				plus[i][c] += h
+				minus[i][c] -= h
+				lp, err1 := BatchCrossEntropy(plus, targets, opts)
+				lm, err2 := BatchCrossEntropy(minus, targets, opts)
+
+				// Assert
+				assert.NoError(t, err1)
+				assert.NoError(t, err2)
+				assert.InDelta(t, (lp.Loss-lm.Loss)/(2*h), res.Grad[i][c], 1e-6)
+			}
+		}
+	})
+
+	t.Run("is stable for extreme logits", func(t *testing.T) {
+		// Act
+		res, err := BatchCrossEntropy([][]float64{{0, -5000}}, []int{1}, CrossEntropyOptions{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 5000.0, res.Loss, 1e-9)
+	})
+
+	t.Run("returns errors for invalid input", func(t *testing.T) {
+		// Arrange
+		cases := map[string]struct {
+			logits  [][]float64
+			targets []int
+			opts    CrossEntropyOptions
+		}{
+			"no rows":             {[][]float64{}, []int{}, CrossEntropyOptions{}},
+			"target mismatch":     {logits, []int{0}, CrossEntropyOptions{}},
+			"target range":        {logits, []int{0, 3, 1}, CrossEntropyOptions{}},
+			"ragged logits":       {[][]float64{{1, 2}, {1}}, []int{0, 0}, CrossEntropyOptions{}},
+			"NaN logit":           {[][]float64{{math.NaN(), 1}}, []int{0}, CrossEntropyOptions{}},
+			"weights length":      {logits, targets, CrossEntropyOptions{Weights: []float64{1}}},
+			"negative weight":     {logits, targets, CrossEntropyOptions{Weights: []float64{1, -1, 1}}},
+			"smoothing range":     {logits, targets, CrossEntropyOptions{LabelSmoothing: 1}},
+			"unknown reduction":   {logits, targets, CrossEntropyOptions{Reduction: Reduction(9)}},
+			"all samples ignored": {[][]float64{{1, 2}}, []int{0}, CrossEntropyOptions{IgnoreIndex: new(int)}},
+		}
+
+		for name, tc := range cases {
+			// Act
+			_, err := BatchCrossEntropy(tc.logits, tc.targets, tc.opts)
+
+			// Assert
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+func cloneMatrix(m [][]float64) [][]float64 {
+	out := make([][]float64, len(m))
+	for i, row := range m {
+		out[i] = append([]float64(nil), row...)
+	}
+	return out
+}
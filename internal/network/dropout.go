@@ -0,0 +1,147 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// TrainingMode is implemented by layers that behave differently while
+// training than during evaluation.
+type TrainingMode interface {
+	SetTraining(training bool)
+	// Training reports whether the layer, or any layer it contains, is in
+	// training mode.
+	Training() bool
+}
+
+// Dropout zeroes each element with probability Rate while training and scales
+// the survivors by 1/(1-Rate) (inverted dropout), so evaluation needs no
+// rescaling and is the identity.
+type Dropout struct {
+	Rate float64
+
+	rng      *rand.Rand
+	training bool
+	// mask holds the scale applied to each element by the last Forward, nil
+	// when it was the identity. masks is the same for ForwardBatch.
+	mask  []float64
+	masks [][]float64
+}
+
+// NewDropout creates a Dropout in training mode with a seeded RNG so masks are
+// reproducible.
+func NewDropout(rate float64, seed uint64) (*Dropout, error) {
+	d := &Dropout{
+		Rate:     rate,
+		rng:      rand.New(rand.NewPCG(seed, seed)),
+		training: true,
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *Dropout) Validate() error {
+	if d.Rate < 0 || d.Rate >= 1 || math.IsNaN(d.Rate) {
+		return fmt.Errorf("dropout rate must be in [0, 1), got %v", d.Rate)
+	}
+
+	return nil
+}
+
+func (d *Dropout) SetTraining(training bool) {
+	d.training = training
+}
+
+func (d *Dropout) Training() bool {
+	return d.training
+}
+
+func (d *Dropout) Forward(x []float64) ([]float64, error) {
+	if len(x) == 0 {
+		return nil, fmt.Errorf("input vector must have length")
+	}
+	if d.rng == nil {
+		return nil, fmt.Errorf("dropout must be created with NewDropout")
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	output := append([]float64(nil), x...)
+	if !d.training || d.Rate == 0 {
+		d.mask = nil
+		return output, nil
+	}
+
+	scale := 1 / (1 - d.Rate)
+	d.mask = make([]float64, len(x))
+	for i := range output {
+		if d.rng.Float64() >= d.Rate {
+			d.mask[i] = scale
+		}
+		output[i] *= d.mask[i]
+	}
+
+	return output, nil
+}
+
+// Backward applies the mask of the last Forward to dy.
+func (d *Dropout) Backward(dy []float64) ([]float64, error) {
+	dx := append([]float64(nil), dy...)
+	if d.mask == nil {
+		return dx, nil
+	}
+	if len(dy) != len(d.mask) {
+		return nil, fmt.Errorf("dimension mismatch: dy has length %d, expected %d", len(dy), len(d.mask))
+	}
+
+	for i := range dx {
+		dx[i] *= d.mask[i]
+	}
+
+	return dx, nil
+}
+
+func (d *Dropout) ForwardBatch(X [][]float64) ([][]float64, error) {
+	output := make([][]float64, len(X))
+	masks := make([][]float64, len(X))
+
+	for idx, x := range X {
+		y, err := d.Forward(x)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("Dropout ForwardBatch failed on row %d", idx), err)
+		}
+		output[idx] = y
+		masks[idx] = d.mask
+	}
+	d.masks = masks
+
+	return output, nil
+}
+
+func (d *Dropout) BackwardBatch(dY [][]float64) ([][]float64, error) {
+	if len(dY) != len(d.masks) {
+		return nil, fmt.Errorf("dimension mismatch: dY has %d rows, last batch had %d", len(dY), len(d.masks))
+	}
+
+	dX := make([][]float64, len(dY))
+	for idx, dy := range dY {
+		d.mask = d.masks[idx]
+		dx, err := d.Backward(dy)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("Dropout BackwardBatch failed on row %d", idx), err)
+		}
+		dX[idx] = dx
+	}
+
+	return dX, nil
+}
+
+func (d *Dropout) Params() []Param {
+	return nil
+}
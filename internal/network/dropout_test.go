@@ -0,0 +1,147 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDropout(t *testing.T) {
+	ones := func(n int) []float64 {
+		v := make([]float64, n)
+		for i := range v {
+			v[i] = 1
+		}
+		return v
+	}
+
+	t.Run("training zeroes elements and scales survivors by 1/(1-rate)", func(t *testing.T) {
+		// Arrange
+		d, err := NewDropout(0.25, 1)
+		assert.NoError(t, err)
+
+		// Act
+		out, err := d.Forward(ones(10000))
+
+		// Assert
+		assert.NoError(t, err)
+		kept, sum := 0, 0.0
+		for _, v := range out {
+			if v != 0 {
+				kept++
+				assert.InDelta(t, 1/0.75, v, 1e-12)
+			}
+			sum += v
+		}
+		assert.InDelta(t, 0.75, float64(kept)/10000, 0.02)
+		assert.InDelta(t, 1.0, sum/10000, 0.03)
+	})
+
+	t.Run("same seed gives the same mask", func(t *testing.T) {
+		// Arrange
+		a, err := NewDropout(0.5, 42)
+		assert.NoError(t, err)
+		b, err := NewDropout(0.5, 42)
+		assert.NoError(t, err)
+
+		// Act
+		outA, errA := a.Forward(ones(32))
+		outB, errB := b.Forward(ones(32))
+
+		// Assert
+		assert.NoError(t, errA)
+		assert.NoError(t, errB)
+		assert.Equal(t, outA, outB)
+	})
+
+	t.Run("evaluation is the identity", func(t *testing.T) {
+		// Arrange
+		d, err := NewDropout(0.9, 3)
+		assert.NoError(t, err)
+		d.SetTraining(false)
+		x := []float64{1.5, -2, 3}
+
+		// Act
+		out, err := d.Forward(x)
+		assert.NoError(t, err)
+		dx, err := d.Backward([]float64{1, 1, 1})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, x, out)
+		assert.Equal(t, []float64{1, 1, 1}, dx)
+		assert.False(t, d.Training())
+	})
+
+	t.Run("Backward applies the forward mask", func(t *testing.T) {
+		// Arrange
+		d, err := NewDropout(0.5, 7)
+		assert.NoError(t, err)
+		out, err := d.Forward(ones(16))
+		assert.NoError(t, err)
+
+		// Act
+		dx, err := d.Backward(ones(16))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, out, dx)
+	})
+
+	t.Run("NewDropout rejects rates outside [0, 1)", func(t *testing.T) {
+		// Act
+		_, errLow := NewDropout(-0.1, 0)
+		_, errHigh := NewDropout(1, 0)
+		_, errNaN := NewDropout(math.NaN(), 0)
+
+		// Assert
+		assert.Error(t, errLow)
+		assert.Error(t, errHigh)
+		assert.Error(t, errNaN)
+	})
+
+	t.Run("Forward rejects a rate changed to an invalid value", func(t *testing.T) {
+		// Arrange
+		d, err := NewDropout(0.5, 0)
+		assert.NoError(t, err)
+		d.Rate = 1
+
+		// Act
+		_, err = d.Forward([]float64{1, 2, 3})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestMLPTrainingMode(t *testing.T) {
+	t.Run("MLP Forward is deterministic in evaluation", func(t *testing.T) {
+		// Arrange
+		d, err := NewDropout(0.5, 1)
+		assert.NoError(t, err)
+		mlp := &MLP{
+			Hidden: Block{
+				LinearLayer:  LinearLayer{In: 2, Out: 8, W: [][]float64{{1, 0}, {0, 1}, {1, 1}, {1, -1}, {2, 0}, {0, 2}, {1, 2}, {2, 1}}},
+				Nonlinearity: ReLU{},
+				Dropout:      d,
+			},
+			Out: LinearLayer{In: 8, Out: 1, W: [][]float64{{1, 1, 1, 1, 1, 1, 1, 1}}},
+		}
+		x := []float64{1, 0.5}
+
+		// Act
+		mlp.SetTraining(false)
+		a, err1 := mlp.Forward(x)
+		b, err2 := mlp.Forward(x)
+		mlp.SetTraining(true)
+		c, err3 := mlp.Forward(x)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.NoError(t, err3)
+		assert.Equal(t, a, b)
+		assert.NotEqual(t, a, c)
+	})
+}
@@ -0,0 +1,110 @@
+package network
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// Embedding maps token ids (e.g. from Vocab.Encode) to learned vectors.
+type Embedding struct {
+	Vocab int
+	Dim   int
+	// Table holds one row per token id.
+	Table     [][]float64
+	GradTable [][]float64
+
+	ids []int
+}
+
+// NewEmbedding creates a table with entries drawn from N(0, 1).
+func NewEmbedding(vocab, dim int, rng *rand.Rand) (*Embedding, error) {
+	if vocab <= 0 || dim <= 0 {
+		return nil, fmt.Errorf("invalid embedding dims: Vocab=%d Dim=%d", vocab, dim)
+	}
+	if rng == nil {
+		return nil, fmt.Errorf("rng is required")
+	}
+
+	table := make([][]float64, vocab)
+	for id := range table {
+		table[id] = make([]float64, dim)
+		for j := range table[id] {
+			table[id][j] = rng.NormFloat64()
+		}
+	}
+
+	return &Embedding{Vocab: vocab, Dim: dim, Table: table}, nil
+}
+
+func (e *Embedding) Validate() error {
+	if e.Vocab <= 0 || e.Dim <= 0 {
+		return fmt.Errorf("invalid embedding dims: Vocab=%d Dim=%d", e.Vocab, e.Dim)
+	}
+	if len(e.Table) != e.Vocab {
+		return fmt.Errorf("dimension mismatch: table has %d rows, expected %d", len(e.Table), e.Vocab)
+	}
+	for id, row := range e.Table {
+		if len(row) != e.Dim {
+			return fmt.Errorf("dimension mismatch: table row %d has length %d, expected %d", id, len(row), e.Dim)
+		}
+	}
+
+	return nil
+}
+
+// ForwardSequence returns a copy of the embedding of each id.
+func (e *Embedding) ForwardSequence(ids []int) ([][]float64, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("ids must have length")
+	}
+
+	output := make([][]float64, len(ids))
+	for t, id := range ids {
+		if id < 0 || id >= e.Vocab {
+			return nil, fmt.Errorf("id %d at position %d out of range for vocab of %d", id, t, e.Vocab)
+		}
+		output[t] = append([]float64(nil), e.Table[id]...)
+	}
+	e.ids = append([]int(nil), ids...)
+
+	return output, nil
+}
+
+// BackwardSequence accumulates the gradient of each looked-up row.
+func (e *Embedding) BackwardSequence(dX [][]float64) error {
+	if len(dX) != len(e.ids) {
+		return fmt.Errorf("dimension mismatch: dX has %d rows, last sequence had %d", len(dX), len(e.ids))
+	}
+
+	e.ensureGrads()
+	for t, dx := range dX {
+		if len(dx) != e.Dim {
+			return fmt.Errorf("dimension mismatch: dX row %d has length %d, expected %d", t, len(dx), e.Dim)
+		}
+		for j, g := range dx {
+			e.GradTable[e.ids[t]][j] += g
+		}
+	}
+
+	return nil
+}
+
+func (e *Embedding) Params() []Param {
+	e.ensureGrads()
+
+	return []Param{{Name: "table", Value: e.Table, Grad: e.GradTable}}
+}
+
+func (e *Embedding) ensureGrads() {
+	if len(e.GradTable) != len(e.Table) {
+		e.GradTable = make([][]float64, len(e.Table))
+	}
+	for id, row := range e.Table {
+		if len(e.GradTable[id]) != len(row) {
+			e.GradTable[id] = make([]float64, len(row))
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package network
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedding(t *testing.T) {
+	t.Run("looks up a copy of each row", func(t *testing.T) {
+		// Arrange
+		e := &Embedding{Vocab: 3, Dim: 2, Table: [][]float64{{1, 2}, {3, 4}, {5, 6}}}
+
+		// Act
+		X, err := e.ForwardSequence([]int{2, 0})
+		X[0][0] = 99
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{99, 6}, {1, 2}}, X)
+		assert.Equal(t, []float64{5, 6}, e.Table[2])
+	})
+
+	t.Run("accumulates gradients for repeated ids", func(t *testing.T) {
+		// Arrange
+		e := &Embedding{Vocab: 3, Dim: 2, Table: [][]float64{{1, 2}, {3, 4}, {5, 6}}}
+		_, err := e.ForwardSequence([]int{1, 1, 0})
+		assert.NoError(t, err)
+
+		// Act
+		err = e.BackwardSequence([][]float64{{1, 0}, {2, 1}, {0.5, 0.5}})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{0.5, 0.5}, {3, 1}, {0, 0}}, e.GradTable)
+	})
+
+	t.Run("rejects ids outside the vocab", func(t *testing.T) {
+		// Arrange
+		e, err := NewEmbedding(3, 2, rand.New(rand.NewPCG(1, 1)))
+		assert.NoError(t, err)
+
+		// Act
+		_, err = e.ForwardSequence([]int{0, 3})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,171 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// GradCheckConfig configures a finite-difference gradient check. Zero values
+// fall back to sensible defaults.
+type GradCheckConfig struct {
+	// Epsilon is the central difference step. Defaults to 1e-6.
+	Epsilon float64
+	// Floor is the smallest denominator used for relative errors, so that
+	// gradients which are (nearly) zero are compared absolutely rather than
+	// amplifying rounding noise. Defaults to 1e-3.
+	Floor float64
+	// Seed seeds the random upstream gradient used by GradCheck.
+	Seed uint64
+}
+
+// GradCheckResult compares the gradient of a single scalar parameter.
+type GradCheckResult struct {
+	Name     string
+	Analytic float64
+	Numeric  float64
+	RelError float64
+}
+
+// GradCheckReport holds every compared element and the worst offender.
+type GradCheckReport struct {
+	Results []GradCheckResult
+	Worst   GradCheckResult
+}
+
+// Check returns an error describing the worst element if any relative error
+// exceeds tol.
+func (r GradCheckReport) Check(tol float64) error {
+	if r.Worst.RelError > tol {
+		return fmt.Errorf("gradient check failed at %s: analytic=%g numeric=%g relative error=%g > %g",
+			r.Worst.Name, r.Worst.Analytic, r.Worst.Numeric, r.Worst.RelError, tol)
+	}
+
+	return nil
+}
+
+// GradCheck verifies a layer's Backward against central differences of its
+// Forward. The scalar loss is sum(dy * Forward(x)) for a fixed random dy, so
+// every output contributes. Every parameter and every element of x is
+// perturbed. Inputs should avoid kinks such as ReLU at 0.
+func GradCheck(layer Layer, x []float64, cfg GradCheckConfig) (GradCheckReport, error) {
+	if len(x) == 0 {
+		return GradCheckReport{}, fmt.Errorf("input vector must have length")
+	}
+
+	input := append([]float64(nil), x...)
+	dx := make([]float64, len(x))
+
+	out, err := layer.Forward(input)
+	if err != nil {
+		return GradCheckReport{}, errors.Join(errors.New("grad check unable to forward layer"), err)
+	}
+
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+	dy := make([]float64, len(out))
+	for i := range dy {
+		dy[i] = rng.Float64()*2 - 1
+	}
+
+	loss := func() (float64, error) {
+		y, err := layer.Forward(input)
+		if err != nil {
+			return 0, err
+		}
+
+		total := 0.0
+		for i := range y {
+			total += dy[i] * y[i]
+		}
+		return total, nil
+	}
+
+	backward := func() error {
+		if _, err := layer.Forward(input); err != nil {
+			return err
+		}
+
+		grad, err := layer.Backward(dy)
+		if err != nil {
+			return err
+		}
+		copy(dx, grad)
+		return nil
+	}
+
+	params := append(layer.Params(), Param{Name: "input", Value: [][]float64{input}, Grad: [][]float64{dx}})
+
+	return GradCheckLoss(params, loss, backward, cfg)
+}
+
+// GradCheckLoss is the general form of GradCheck for any scalar loss. loss
+// evaluates the loss for the current parameter values and backward populates
+// the Grad buffers of params; the buffers are zeroed before backward runs.
+func GradCheckLoss(params []Param, loss func() (float64, error), backward func() error, cfg GradCheckConfig) (GradCheckReport, error) {
+	eps := cfg.Epsilon
+	if eps == 0 {
+		eps = 1e-6
+	}
+	floor := cfg.Floor
+	if floor == 0 {
+		floor = 1e-3
+	}
+
+	ZeroGrad(params)
+	if err := backward(); err != nil {
+		return GradCheckReport{}, errors.Join(errors.New("grad check unable to run backward"), err)
+	}
+
+	report := GradCheckReport{}
+
+	for _, p := range params {
+		if len(p.Value) != len(p.Grad) {
+			return GradCheckReport{}, fmt.Errorf("param %s has %d value rows and %d grad rows", p.Name, len(p.Value), len(p.Grad))
+		}
+
+		for r, row := range p.Value {
+			if len(row) != len(p.Grad[r]) {
+				return GradCheckReport{}, fmt.Errorf("param %s row %d has %d values and %d grads", p.Name, r, len(row), len(p.Grad[r]))
+			}
+
+			for c := range row {
+				orig := row[c]
+
+				row[c] = orig + eps
+				lp, err := loss()
+				if err != nil {
+					row[c] = orig
+					return GradCheckReport{}, errors.Join(fmt.Errorf("grad check unable to evaluate loss at %s[%d][%d]", p.Name, r, c), err)
+				}
+
+				row[c] = orig - eps
+				lm, err := loss()
+				row[c] = orig
+				if err != nil {
+					return GradCheckReport{}, errors.Join(fmt.Errorf("grad check unable to evaluate loss at %s[%d][%d]", p.Name, r, c), err)
+				}
+
+				analytic := p.Grad[r][c]
+				numeric := (lp - lm) / (2 * eps)
+				result := GradCheckResult{
+					Name:     fmt.Sprintf("%s[%d][%d]", p.Name, r, c),
+					Analytic: analytic,
+					Numeric:  numeric,
+					RelError: math.Abs(analytic-numeric) / max(math.Abs(analytic), math.Abs(numeric), floor),
+				}
+
+				report.Results = append(report.Results, result)
+				if result.RelError >= report.Worst.RelError {
+					report.Worst = result
+				}
+			}
+		}
+	}
+
+	if len(report.Results) == 0 {
+		return GradCheckReport{}, fmt.Errorf("no parameters to check")
+	}
+
+	return report, nil
+}
@@ -0,0 +1,98 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// brokenLayer doubles its input but reports the gradient of the identity.
+type brokenLayer struct{}
+
+func (brokenLayer) Forward(x []float64) ([]float64, error) {
+	y := make([]float64, len(x))
+	for i := range x {
+		y[i] = 2 * x[i]
+	}
+	return y, nil
+}
+
+func (brokenLayer) Backward(dy []float64) ([]float64, error) {
+	return append([]float64(nil), dy...), nil
+}
+
+func (brokenLayer) Params() []Param { return nil }
+
+func TestGradCheck(t *testing.T) {
+	t.Run("passes for a correct LinearLayer", func(t *testing.T) {
+		// Arrange
+		ll := &LinearLayer{
+			In:  3,
+			Out: 2,
+			W:   [][]float64{{0.3, -1.2, 0.8}, {1.1, 0.4, -0.6}},
+			B:   []float64{0.1, -0.2},
+		}
+
+		// Act
+		report, err := GradCheck(ll, []float64{0.5, -1.5, 2.0}, GradCheckConfig{Seed: 1})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-6))
+		// 6 weights, 2 biases and 3 inputs
+		assert.Len(t, report.Results, 11)
+	})
+
+	t.Run("detects an incorrect backward", func(t *testing.T) {
+		// Act
+		report, err := GradCheck(brokenLayer{}, []float64{1, 2}, GradCheckConfig{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Error(t, report.Check(1e-6))
+		assert.InDelta(t, 0.5, report.Worst.RelError, 1e-6)
+	})
+
+	t.Run("returns an error for an empty input", func(t *testing.T) {
+		// Act
+		_, err := GradCheck(brokenLayer{}, []float64{}, GradCheckConfig{})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestGradCheckLoss(t *testing.T) {
+	t.Run("checks an arbitrary scalar loss", func(t *testing.T) {
+		// Arrange
+		// loss = a^2 * b, so dL/da = 2ab and dL/db = a^2
+		value := [][]float64{{1.5, -2.0}}
+		grad := [][]float64{{0, 0}}
+		params := []Param{{Name: "ab", Value: value, Grad: grad}}
+
+		loss := func() (float64, error) {
+			return value[0][0] * value[0][0] * value[0][1], nil
+		}
+		backward := func() error {
+			grad[0][0] += 2 * value[0][0] * value[0][1]
+			grad[0][1] += value[0][0] * value[0][0]
+			return nil
+		}
+
+		// Act
+		report, err := GradCheckLoss(params, loss, backward, GradCheckConfig{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-7))
+		assert.InDeltaSlice(t, []float64{1.5, -2.0}, value[0], 0, "values are restored")
+	})
+
+	t.Run("returns an error when there is nothing to check", func(t *testing.T) {
+		// Act
+		_, err := GradCheckLoss(nil, func() (float64, error) { return 0, nil }, func() error { return nil }, GradCheckConfig{})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
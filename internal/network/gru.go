@@ -0,0 +1,126 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// GRUCell follows the PyTorch formulation:
+//
+//	r = σ(Reset(x, h))
+//	z = σ(Update(x, h))
+//	n = tanh(Candidate.Input x + r ⊙ Candidate.Hidden h)
+//	h' = (1 - z) ⊙ n + z ⊙ h
+//
+// Candidate.Hidden carries its own bias so that it is gated by r.
+type GRUCell struct {
+	Reset     Gate
+	Update    Gate
+	Candidate Gate
+}
+
+type gruCache struct {
+	x, h       []float64
+	r, z, n    []float64
+	candHidden []float64
+}
+
+func NewGRUCell(in, hidden int, rng *rand.Rand) (*GRUCell, error) {
+	reset, err := newGate(in, hidden, false, rng)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create gru reset gate"), err)
+	}
+	update, err := newGate(in, hidden, false, rng)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create gru update gate"), err)
+	}
+	candidate, err := newGate(in, hidden, true, rng)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create gru candidate gate"), err)
+	}
+
+	return &GRUCell{Reset: reset, Update: update, Candidate: candidate}, nil
+}
+
+func (c *GRUCell) InputSize() int  { return c.Reset.Input.In }
+func (c *GRUCell) HiddenSize() int { return c.Reset.Hidden.Out }
+
+func (c *GRUCell) ZeroState() CellState {
+	return CellState{H: make([]float64, c.HiddenSize())}
+}
+
+func (c *GRUCell) Step(x []float64, prev CellState) (CellState, any, error) {
+	r, err := c.Reset.preActivation(x, prev.H)
+	if err != nil {
+		return CellState{}, nil, err
+	}
+	z, err := c.Update.preActivation(x, prev.H)
+	if err != nil {
+		return CellState{}, nil, err
+	}
+	nx, nh, err := c.Candidate.forward(x, prev.H)
+	if err != nil {
+		return CellState{}, nil, err
+	}
+
+	n := make([]float64, len(nx))
+	h := make([]float64, len(nx))
+	for i := range h {
+		r[i] = sigmoid(r[i])
+		z[i] = sigmoid(z[i])
+		n[i] = math.Tanh(nx[i] + r[i]*nh[i])
+		h[i] = (1-z[i])*n[i] + z[i]*prev.H[i]
+	}
+
+	cache := &gruCache{
+		x: append([]float64(nil), x...), h: append([]float64(nil), prev.H...),
+		r: r, z: z, n: n, candHidden: nh,
+	}
+
+	return CellState{H: h}, cache, nil
+}
+
+func (c *GRUCell) StepBackward(cache any, dNext CellState) ([]float64, CellState, error) {
+	s, ok := cache.(*gruCache)
+	if !ok {
+		return nil, CellState{}, fmt.Errorf("gru cell given cache of type %T", cache)
+	}
+	if len(dNext.H) != len(s.h) {
+		return nil, CellState{}, fmt.Errorf("dimension mismatch: dh has length %d, expected %d", len(dNext.H), len(s.h))
+	}
+
+	size := len(s.h)
+	dh := make([]float64, size)
+	dnPre := make([]float64, size)
+	dnHidden := make([]float64, size)
+	drPre := make([]float64, size)
+	dzPre := make([]float64, size)
+	for i, g := range dNext.H {
+		dh[i] = g * s.z[i]
+		dnPre[i] = g * (1 - s.z[i]) * (1 - s.n[i]*s.n[i])
+		dnHidden[i] = dnPre[i] * s.r[i]
+		drPre[i] = dnPre[i] * s.candHidden[i] * s.r[i] * (1 - s.r[i])
+		dzPre[i] = g * (s.h[i] - s.n[i]) * s.z[i] * (1 - s.z[i])
+	}
+
+	dx := make([]float64, len(s.x))
+	if err := c.Reset.backward(s.x, s.h, drPre, drPre, dx, dh); err != nil {
+		return nil, CellState{}, err
+	}
+	if err := c.Update.backward(s.x, s.h, dzPre, dzPre, dx, dh); err != nil {
+		return nil, CellState{}, err
+	}
+	if err := c.Candidate.backward(s.x, s.h, dnPre, dnHidden, dx, dh); err != nil {
+		return nil, CellState{}, err
+	}
+
+	return dx, CellState{H: dh}, nil
+}
+
+func (c *GRUCell) Params() []Param {
+	params := c.Reset.params("reset")
+	params = append(params, c.Update.params("update")...)
+	return append(params, c.Candidate.params("candidate")...)
+}
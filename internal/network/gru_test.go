@@ -0,0 +1,53 @@
+package network
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGRUCell(t *testing.T) {
+	t.Run("gradients match finite differences", func(t *testing.T) {
+		// Arrange
+		r := newTestRecurrent(t, func(in, hidden int, rng *rand.Rand) (Cell, error) { return NewGRUCell(in, hidden, rng) })
+
+		// Act
+		report, err := gradCheckSequence(r)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-5))
+	})
+
+	t.Run("keeps the previous state when the update gate saturates", func(t *testing.T) {
+		// Arrange
+		cell, err := NewGRUCell(2, 3, rand.New(rand.NewPCG(1, 1)))
+		assert.NoError(t, err)
+		for j := range cell.Update.Input.B {
+			cell.Update.Input.B[j] = 100
+		}
+		prev := CellState{H: []float64{0.2, -0.5, 0.9}}
+
+		// Act
+		next, _, err := cell.Step([]float64{1, -1}, prev)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, prev.H, next.H, 1e-9)
+	})
+
+	t.Run("names params by gate", func(t *testing.T) {
+		// Arrange
+		cell, err := NewGRUCell(2, 3, rand.New(rand.NewPCG(1, 1)))
+		assert.NoError(t, err)
+
+		// Act
+		params := cell.Params()
+
+		// Assert
+		assert.Len(t, params, 10)
+		assert.Equal(t, "reset.input.W", params[0].Name)
+		assert.Equal(t, "candidate.hidden.B", params[9].Name)
+	})
+}
@@ -0,0 +1,14 @@
+package network
+
+// ForwardHook observes a layer's input and output each time Forward succeeds.
+// Hooks must treat both slices as read-only.
+type ForwardHook func(in, out []float64)
+
+// forwardHooks is embedded state for layers that support hooks.
+type forwardHooks []ForwardHook
+
+func (h forwardHooks) run(in, out []float64) {
+	for _, hook := range h {
+		hook(in, out)
+	}
+}
@@ -0,0 +1,77 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForwardHooks(t *testing.T) {
+	t.Run("LinearLayer hook receives input and pre-activation output", func(t *testing.T) {
+		// Arrange
+		ll := LinearLayer{In: 2, Out: 1, W: [][]float64{{1, -1}}, B: []float64{0.5}}
+		var gotIn, gotOut []float64
+		ll.RegisterForwardHook(func(in, out []float64) {
+			gotIn, gotOut = in, out
+		})
+
+		// Act
+		_, err := ll.Forward([]float64{1, 3})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{1, 3}, gotIn)
+		assert.InDeltaSlice(t, []float64{-1.5}, gotOut, 1e-12)
+	})
+
+	t.Run("Block hook receives input and activation", func(t *testing.T) {
+		// Arrange
+		block := Block{
+			LinearLayer:  LinearLayer{In: 2, Out: 1, W: [][]float64{{1, -1}}},
+			Nonlinearity: ReLU{},
+		}
+		var gotOut []float64
+		block.RegisterForwardHook(func(_, out []float64) {
+			gotOut = out
+		})
+
+		// Act
+		_, err := block.Forward([]float64{1, 3})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{0}, gotOut)
+	})
+
+	t.Run("hooks run in registration order and can be cleared", func(t *testing.T) {
+		// Arrange
+		ll := LinearLayer{In: 1, Out: 1, W: [][]float64{{1}}}
+		calls := make([]int, 0)
+		ll.RegisterForwardHook(func(_, _ []float64) { calls = append(calls, 1) })
+		ll.RegisterForwardHook(func(_, _ []float64) { calls = append(calls, 2) })
+
+		// Act
+		_, err1 := ll.Forward([]float64{1})
+		ll.ClearForwardHooks()
+		_, err2 := ll.Forward([]float64{1})
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, []int{1, 2}, calls)
+	})
+
+	t.Run("hooks do not run when Forward fails", func(t *testing.T) {
+		// Arrange
+		ll := LinearLayer{In: 2, Out: 1, W: [][]float64{{1, 1}}}
+		called := false
+		ll.RegisterForwardHook(func(_, _ []float64) { called = true })
+
+		// Act
+		_, err := ll.Forward([]float64{1})
+
+		// Assert
+		assert.Error(t, err)
+		assert.False(t, called)
+	})
+}
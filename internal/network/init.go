@@ -0,0 +1,49 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// Initializer draws the initial value of one weight of a layer with the given
+// fan-in and fan-out.
+type Initializer func(rng *rand.Rand, fanIn, fanOut int) float64
+
+// XavierUniform (Glorot) samples from U(-a, a) with a = sqrt(6/(fanIn+fanOut)),
+// suited to tanh and sigmoid layers.
+func XavierUniform(rng *rand.Rand, fanIn, fanOut int) float64 {
+	limit := math.Sqrt(6 / float64(fanIn+fanOut))
+	return (2*rng.Float64() - 1) * limit
+}
+
+// HeNormal (Kaiming) samples from N(0, 2/fanIn), suited to ReLU layers.
+func HeNormal(rng *rand.Rand, fanIn, _ int) float64 {
+	return rng.NormFloat64() * math.Sqrt(2/float64(fanIn))
+}
+
+// NewLinearLayer creates an In x Out layer with weights drawn from init and,
+// when bias is set, a zero bias.
+func NewLinearLayer(in, out int, bias bool, init Initializer, rng *rand.Rand) (*LinearLayer, error) {
+	if in <= 0 || out <= 0 {
+		return nil, fmt.Errorf("invalid layer dims: In=%d Out=%d", in, out)
+	}
+	if init == nil || rng == nil {
+		return nil, fmt.Errorf("initializer and rng are required")
+	}
+
+	W := make([][]float64, out)
+	for o := range W {
+		W[o] = make([]float64, in)
+		for i := range W[o] {
+			W[o][i] = init(rng, in, out)
+		}
+	}
+
+	l := &LinearLayer{In: in, Out: out, W: W}
+	if bias {
+		l.B = make([]float64, out)
+	}
+
+	return l, nil
+}
@@ -0,0 +1,74 @@
+package network
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLinearLayer(t *testing.T) {
+	t.Run("XavierUniform stays within its limit", func(t *testing.T) {
+		// Arrange
+		rng := rand.New(rand.NewPCG(1, 1))
+		limit := math.Sqrt(6.0 / (20 + 10))
+
+		// Act
+		l, err := NewLinearLayer(20, 10, true, XavierUniform, rng)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, l.Validate())
+		assert.Len(t, l.B, 10)
+		for _, row := range l.W {
+			assert.Len(t, row, 20)
+			for _, w := range row {
+				assert.LessOrEqual(t, math.Abs(w), limit)
+			}
+		}
+	})
+
+	t.Run("HeNormal has variance near 2/fanIn", func(t *testing.T) {
+		// Arrange
+		rng := rand.New(rand.NewPCG(2, 2))
+
+		// Act
+		l, err := NewLinearLayer(50, 200, false, HeNormal, rng)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, l.B)
+		sumSq := 0.0
+		for _, row := range l.W {
+			for _, w := range row {
+				sumSq += w * w
+			}
+		}
+		assert.InDelta(t, 2.0/50, sumSq/(50*200), 0.004)
+	})
+
+	t.Run("same seed gives the same weights", func(t *testing.T) {
+		// Act
+		a, err1 := NewLinearLayer(3, 2, false, HeNormal, rand.New(rand.NewPCG(7, 7)))
+		b, err2 := NewLinearLayer(3, 2, false, HeNormal, rand.New(rand.NewPCG(7, 7)))
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, a.W, b.W)
+	})
+
+	t.Run("returns errors for invalid arguments", func(t *testing.T) {
+		// Arrange
+		rng := rand.New(rand.NewPCG(1, 1))
+
+		// Act
+		_, errDims := NewLinearLayer(0, 2, false, HeNormal, rng)
+		_, errInit := NewLinearLayer(2, 2, false, nil, rng)
+
+		// Assert
+		assert.Error(t, errDims)
+		assert.Error(t, errInit)
+	})
+}
@@ -0,0 +1,191 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// LayerNorm normalises each sample across its features to zero mean and unit
+// variance, then applies a learnable per-feature Gain and Bias.
+type LayerNorm struct {
+	Size    int
+	Gain    []float64
+	Bias    []float64
+	Epsilon float64
+
+	GradGain []float64
+	GradBias []float64
+
+	cache  normCache
+	caches []normCache
+}
+
+// normCache is what LayerNorm needs from Forward to run Backward.
+type normCache struct {
+	xhat   []float64
+	invStd float64
+}
+
+// NewLayerNorm creates a LayerNorm with Gain 1, Bias 0 and Epsilon 1e-5.
+func NewLayerNorm(size int) (*LayerNorm, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("layer norm size must be positive, got %d", size)
+	}
+
+	gain := make([]float64, size)
+	for i := range gain {
+		gain[i] = 1
+	}
+
+	return &LayerNorm{
+		Size:     size,
+		Gain:     gain,
+		Bias:     make([]float64, size),
+		Epsilon:  1e-5,
+		GradGain: make([]float64, size),
+		GradBias: make([]float64, size),
+	}, nil
+}
+
+func (n *LayerNorm) Validate() error {
+	if n.Size <= 0 {
+		return fmt.Errorf("layer norm size must be positive, got %d", n.Size)
+	}
+	if len(n.Gain) != n.Size || len(n.Bias) != n.Size {
+		return fmt.Errorf("dimension mismatch: gain %d and bias %d, expected %d", len(n.Gain), len(n.Bias), n.Size)
+	}
+	if !(n.Epsilon > 0) {
+		return fmt.Errorf("epsilon must be positive, got %v", n.Epsilon)
+	}
+
+	return nil
+}
+
+func (n *LayerNorm) Forward(x []float64) ([]float64, error) {
+	y, cache, err := n.forward(x)
+	if err != nil {
+		return nil, err
+	}
+	n.cache = cache
+
+	return y, nil
+}
+
+func (n *LayerNorm) Backward(dy []float64) ([]float64, error) {
+	if n.cache.xhat == nil {
+		return nil, fmt.Errorf("LayerNorm Backward called before Forward")
+	}
+
+	return n.backward(n.cache, dy)
+}
+
+func (n *LayerNorm) ForwardBatch(X [][]float64) ([][]float64, error) {
+	output := make([][]float64, len(X))
+	caches := make([]normCache, len(X))
+
+	for idx, x := range X {
+		y, cache, err := n.forward(x)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("LayerNorm ForwardBatch failed on row %d", idx), err)
+		}
+		output[idx] = y
+		caches[idx] = cache
+	}
+	n.caches = caches
+
+	return output, nil
+}
+
+func (n *LayerNorm) BackwardBatch(dY [][]float64) ([][]float64, error) {
+	if len(dY) != len(n.caches) {
+		return nil, fmt.Errorf("dimension mismatch: dY has %d rows, last batch had %d", len(dY), len(n.caches))
+	}
+
+	dX := make([][]float64, len(dY))
+	for idx, dy := range dY {
+		dx, err := n.backward(n.caches[idx], dy)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("LayerNorm BackwardBatch failed on row %d", idx), err)
+		}
+		dX[idx] = dx
+	}
+
+	return dX, nil
+}
+
+func (n *LayerNorm) Params() []Param {
+	n.ensureGrads()
+
+	return []Param{
+		{Name: "gain", Kind: ParamNorm, Value: [][]float64{n.Gain}, Grad: [][]float64{n.GradGain}},
+		{Name: "bias", Kind: ParamBias, Value: [][]float64{n.Bias}, Grad: [][]float64{n.GradBias}},
+	}
+}
+
+func (n *LayerNorm) forward(x []float64) ([]float64, normCache, error) {
+	if err := n.Validate(); err != nil {
+		return nil, normCache{}, errors.Join(errors.New("LayerNorm failed validation"), err)
+	}
+	if len(x) != n.Size {
+		return nil, normCache{}, fmt.Errorf("dimension mismatch: x has length %d, expected %d", len(x), n.Size)
+	}
+
+	size := float64(n.Size)
+	mean := 0.0
+	for _, el := range x {
+		mean += el
+	}
+	mean /= size
+
+	variance := 0.0
+	for _, el := range x {
+		variance += (el - mean) * (el - mean)
+	}
+	variance /= size
+
+	cache := normCache{xhat: make([]float64, n.Size), invStd: 1 / math.Sqrt(variance+n.Epsilon)}
+	y := make([]float64, n.Size)
+	for i, el := range x {
+		cache.xhat[i] = (el - mean) * cache.invStd
+		y[i] = n.Gain[i]*cache.xhat[i] + n.Bias[i]
+	}
+
+	return y, cache, nil
+}
+
+func (n *LayerNorm) backward(cache normCache, dy []float64) ([]float64, error) {
+	if len(dy) != n.Size {
+		return nil, fmt.Errorf("dimension mismatch: dy has length %d, expected %d", len(dy), n.Size)
+	}
+
+	n.ensureGrads()
+
+	// dx = invStd/N * (N*dxhat - sum(dxhat) - xhat*sum(dxhat*xhat))
+	dxhat := make([]float64, n.Size)
+	sumDxhat, sumDxhatXhat := 0.0, 0.0
+	for i, g := range dy {
+		n.GradGain[i] += g * cache.xhat[i]
+		n.GradBias[i] += g
+		dxhat[i] = g * n.Gain[i]
+		sumDxhat += dxhat[i]
+		sumDxhatXhat += dxhat[i] * cache.xhat[i]
+	}
+
+	size := float64(n.Size)
+	dx := make([]float64, n.Size)
+	for i := range dx {
+		dx[i] = cache.invStd / size * (size*dxhat[i] - sumDxhat - cache.xhat[i]*sumDxhatXhat)
+	}
+
+	return dx, nil
+}
+
+func (n *LayerNorm) ensureGrads() {
+	if len(n.GradGain) != len(n.Gain) {
+		n.GradGain = make([]float64, len(n.Gain))
+	}
+	if len(n.GradBias) != len(n.Bias) {
+		n.GradBias = make([]float64, len(n.Bias))
+	}
+}
@@ -0,0 +1,167 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayerNorm(t *testing.T) {
+	t.Run("normalises each sample to zero mean and unit variance", func(t *testing.T) {
+		// Arrange
+		ln, err := NewLayerNorm(4)
+		assert.NoError(t, err)
+
+		// Act
+		y, err := ln.Forward([]float64{1, 2, 3, 10})
+
+		// Assert
+		assert.NoError(t, err)
+		mean, variance := 0.0, 0.0
+		for _, v := range y {
+			mean += v / 4
+		}
+		for _, v := range y {
+			variance += (v - mean) * (v - mean) / 4
+		}
+		assert.InDelta(t, 0.0, mean, 1e-12)
+		assert.InDelta(t, 1.0, variance, 1e-4)
+	})
+
+	t.Run("applies gain and bias", func(t *testing.T) {
+		// Arrange
+		ln, err := NewLayerNorm(2)
+		assert.NoError(t, err)
+		ln.Gain = []float64{2, 3}
+		ln.Bias = []float64{1, -1}
+		ln.Epsilon = 1e-12
+
+		// Act
+		y, err := ln.Forward([]float64{0, 4})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{-1, 2}, y, 1e-9)
+	})
+
+	t.Run("gradients match finite differences", func(t *testing.T) {
+		// Arrange
+		ln, err := NewLayerNorm(3)
+		assert.NoError(t, err)
+		ln.Gain = []float64{1.5, -0.5, 0.8}
+		ln.Bias = []float64{0.1, 0.2, -0.3}
+
+		// Act
+		report, err := GradCheck(ln, []float64{0.4, -1.2, 2.3}, GradCheckConfig{Seed: 5})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-6))
+	})
+
+	t.Run("batch gradients match finite differences", func(t *testing.T) {
+		// Arrange
+		ln, err := NewLayerNorm(3)
+		assert.NoError(t, err)
+		X := [][]float64{{0.4, -1.2, 2.3}, {1.0, 0.5, -0.7}}
+
+		// Act
+		report, err := gradCheckBatch(ln, X)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-6))
+	})
+
+	t.Run("usable as a Block norm", func(t *testing.T) {
+		// Arrange
+		ln, err := NewLayerNorm(3)
+		assert.NoError(t, err)
+		block := &Block{
+			LinearLayer:  LinearLayer{In: 2, Out: 3, W: [][]float64{{0.5, -0.3}, {0.2, 0.8}, {-0.6, 0.4}}, B: []float64{0.1, 0.2, 0.3}},
+			Norm:         ln,
+			Nonlinearity: ReLU{},
+		}
+
+		// Act
+		report, err := GradCheck(block, []float64{1.1, 0.7}, GradCheckConfig{Seed: 2})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-6))
+		assert.Len(t, block.Params(), 4)
+	})
+
+	t.Run("returns errors for invalid sizes", func(t *testing.T) {
+		// Arrange
+		ln, err := NewLayerNorm(2)
+		assert.NoError(t, err)
+
+		// Act
+		_, errNew := NewLayerNorm(0)
+		_, errForward := ln.Forward([]float64{1, 2, 3})
+		_, errBackward := (&LayerNorm{}).Backward([]float64{1})
+		ln.Epsilon = math.NaN()
+		_, errEps := ln.Forward([]float64{1, 2})
+
+		// Assert
+		assert.Error(t, errNew)
+		assert.Error(t, errForward)
+		assert.Error(t, errBackward)
+		assert.ErrorContains(t, errEps, "epsilon")
+	})
+}
+
+// gradCheckBatch runs GradCheckLoss over a BatchLayer with the loss
+// sum(dY * ForwardBatch(X)) for a fixed dY, checking params and inputs.
+func gradCheckBatch(layer BatchLayer, X [][]float64) (GradCheckReport, error) {
+	input := cloneMatrix(X)
+	dX := make([][]float64, len(X))
+	dY := make([][]float64, 0, len(X))
+	for idx, x := range X {
+		dX[idx] = make([]float64, len(x))
+	}
+
+	out, err := layer.ForwardBatch(input)
+	if err != nil {
+		return GradCheckReport{}, err
+	}
+	for idx, y := range out {
+		row := make([]float64, len(y))
+		for j := range row {
+			row[j] = float64((idx+1)*(j+2)%5) - 1.7
+		}
+		dY = append(dY, row)
+	}
+
+	loss := func() (float64, error) {
+		Y, err := layer.ForwardBatch(input)
+		if err != nil {
+			return 0, err
+		}
+		total := 0.0
+		for i := range Y {
+			for j := range Y[i] {
+				total += dY[i][j] * Y[i][j]
+			}
+		}
+		return total, nil
+	}
+	backward := func() error {
+		if _, err := layer.ForwardBatch(input); err != nil {
+			return err
+		}
+		grad, err := layer.BackwardBatch(dY)
+		if err != nil {
+			return err
+		}
+		for idx := range grad {
+			copy(dX[idx], grad[idx])
+		}
+		return nil
+	}
+
+	params := append(layer.Params(), Param{Name: "input", Value: input, Grad: dX})
+	return GradCheckLoss(params, loss, backward, GradCheckConfig{})
+}
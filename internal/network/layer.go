@@ -0,0 +1,61 @@
+package network
+
+// Param is a named view onto trainable values and their gradient buffer.
+// Value and Grad share storage with the owning layer, so an update made
+// through a Param is seen by the layer. A vector parameter such as a bias is
+// exposed as a single row.
+type Param struct {
+	Name  string
+	Kind  ParamKind
+	Value [][]float64
+	Grad  [][]float64
+}
+
+// ParamKind distinguishes parameter groups, e.g. so biases can be excluded
+// from regularisation.
+type ParamKind int
+
+const (
+	ParamWeight ParamKind = iota
+	ParamBias
+	// ParamNorm marks the gain of a normalisation layer.
+	ParamNorm
+)
+
+// Layer is a differentiable vector to vector transform. Forward caches what
+// Backward needs, so Backward always refers to the most recent Forward.
+type Layer interface {
+	Forward(x []float64) ([]float64, error)
+	// Backward takes the gradient of the loss with respect to the last output,
+	// accumulates parameter gradients into the Grad buffers and returns the
+	// gradient with respect to the last input.
+	Backward(dy []float64) ([]float64, error)
+	Params() []Param
+}
+
+// BatchLayer is a Layer that can also process a whole batch, required by
+// layers such as BatchNorm1d whose output depends on the rest of the batch.
+// BackwardBatch refers to the most recent ForwardBatch.
+type BatchLayer interface {
+	Layer
+	ForwardBatch(X [][]float64) ([][]float64, error)
+	BackwardBatch(dY [][]float64) ([][]float64, error)
+}
+
+// ZeroGrad resets every gradient buffer to 0, typically before a new
+// backward pass since gradients accumulate.
+func ZeroGrad(params []Param) {
+	for _, p := range params {
+		for _, row := range p.Grad {
+			clear(row)
+		}
+	}
+}
+
+// prefixParams namespaces the params of a sub-layer, e.g. "hidden.W".
+func prefixParams(prefix string, params []Param) []Param {
+	for i := range params {
+		params[i].Name = prefix + "." + params[i].Name
+	}
+	return params
+}
@@ -0,0 +1,62 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZeroGrad(t *testing.T) {
+	t.Run("clears every gradient buffer in place", func(t *testing.T) {
+		// Arrange
+		ll := &LinearLayer{In: 2, Out: 1, W: [][]float64{{1, 2}}, B: []float64{0.5}}
+		_, err := ll.Forward([]float64{3, 4})
+		assert.NoError(t, err)
+		_, err = ll.Backward([]float64{1})
+		assert.NoError(t, err)
+
+		// Act
+		ZeroGrad(ll.Params())
+
+		// Assert
+		assert.Equal(t, [][]float64{{0, 0}}, ll.GradW)
+		assert.Equal(t, []float64{0}, ll.GradB)
+	})
+}
+
+func TestParams(t *testing.T) {
+	t.Run("MLP params are namespaced by sub-layer", func(t *testing.T) {
+		// Arrange
+		mlp := MLP{
+			Hidden: Block{
+				LinearLayer:  LinearLayer{In: 1, Out: 1, W: [][]float64{{1}}, B: []float64{0}},
+				Nonlinearity: ReLU{},
+			},
+			Out: LinearLayer{In: 1, Out: 1, W: [][]float64{{1}}},
+		}
+
+		// Act
+		params := mlp.Params()
+
+		// Assert
+		names := make([]string, len(params))
+		for i, p := range params {
+			names[i] = p.Name
+		}
+		assert.Equal(t, []string{"hidden.linear.W", "hidden.linear.B", "out.W"}, names)
+	})
+
+	t.Run("updates through a Param are seen by the layer", func(t *testing.T) {
+		// Arrange
+		ll := &LinearLayer{In: 1, Out: 1, W: [][]float64{{1}}, B: []float64{0}}
+
+		// Act
+		params := ll.Params()
+		params[0].Value[0][0] = 5
+		params[1].Value[0][0] = 2
+
+		// Assert
+		assert.Equal(t, 5.0, ll.W[0][0])
+		assert.Equal(t, 2.0, ll.B[0])
+	})
+}
@@ -12,6 +12,16 @@ type LinearLayer struct {
 	Out int
 	W   [][]float64
 	B   []float64
+
+	// GradW and GradB accumulate gradients during Backward. They are
+	// allocated on first use.
+	GradW [][]float64
+	GradB []float64
+
+	// x is the input of the last Forward, kept for Backward.
+	x     []float64
+	xs    [][]float64
+	hooks forwardHooks
 }
 
 func (l *LinearLayer) Validate() error {
@@ -51,9 +61,123 @@ func (l *LinearLayer) Forward(x []float64) ([]float64, error) {
 		return nil, errors.Join(errors.New("LinearLayer Forward failed at MatVecMul"), err)
 	}
 
+	l.x = append([]float64(nil), x...)
+
 	if withBias {
-		return internal.AddVec(output, l.B)
+		output, err = internal.AddVec(output, l.B)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	l.hooks.run(x, output)
+
+	return output, nil
+}
+
+// RegisterForwardHook adds h to the hooks run after every Forward, receiving
+// the input and the pre-activation output.
+func (l *LinearLayer) RegisterForwardHook(h ForwardHook) {
+	l.hooks = append(l.hooks, h)
+}
+
+func (l *LinearLayer) ClearForwardHooks() {
+	l.hooks = nil
+}
+
+// Backward accumulates dL/dW and dL/dB for the last Forward and returns dL/dx.
+func (l *LinearLayer) Backward(dy []float64) ([]float64, error) {
+	if l.x == nil {
+		return nil, fmt.Errorf("LinearLayer Backward called before Forward")
+	}
+
+	return l.backward(l.x, dy)
+}
+
+// ForwardBatch runs Forward on each row of X.
+func (l *LinearLayer) ForwardBatch(X [][]float64) ([][]float64, error) {
+	output := make([][]float64, len(X))
+	xs := make([][]float64, len(X))
+
+	for idx, x := range X {
+		y, err := l.Forward(x)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("LinearLayer ForwardBatch failed on row %d", idx), err)
+		}
+		output[idx] = y
+		xs[idx] = l.x
 	}
 
+	l.xs = xs
+
 	return output, nil
 }
+
+// BackwardBatch accumulates gradients over the rows of the last ForwardBatch.
+func (l *LinearLayer) BackwardBatch(dY [][]float64) ([][]float64, error) {
+	if len(dY) != len(l.xs) {
+		return nil, fmt.Errorf("dimension mismatch: dY has %d rows, last batch had %d", len(dY), len(l.xs))
+	}
+
+	dX := make([][]float64, len(dY))
+	for idx, dy := range dY {
+		dx, err := l.backward(l.xs[idx], dy)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("LinearLayer BackwardBatch failed on row %d", idx), err)
+		}
+		dX[idx] = dx
+	}
+
+	return dX, nil
+}
+
+// backward is Backward for an explicit input x rather than the cached one.
+func (l *LinearLayer) backward(x, dy []float64) ([]float64, error) {
+	if len(dy) != l.Out {
+		return nil, fmt.Errorf("dimension mismatch: dy has length %d, expected %d", len(dy), l.Out)
+	}
+	if len(x) != l.In {
+		return nil, fmt.Errorf("dimension mismatch: x has length %d, expected %d", len(x), l.In)
+	}
+
+	l.ensureGrads()
+
+	dx := make([]float64, l.In)
+	for o, row := range l.W {
+		for i, w := range row {
+			l.GradW[o][i] += dy[o] * x[i]
+			dx[i] += w * dy[o]
+		}
+		if len(l.B) != 0 {
+			l.GradB[o] += dy[o]
+		}
+	}
+
+	return dx, nil
+}
+
+// Params exposes W and, when present, B.
+func (l *LinearLayer) Params() []Param {
+	l.ensureGrads()
+
+	params := []Param{{Name: "W", Value: l.W, Grad: l.GradW}}
+	if len(l.B) != 0 {
+		params = append(params, Param{Name: "B", Kind: ParamBias, Value: [][]float64{l.B}, Grad: [][]float64{l.GradB}})
+	}
+
+	return params
+}
+
+func (l *LinearLayer) ensureGrads() {
+	if len(l.GradW) != len(l.W) {
+		l.GradW = make([][]float64, len(l.W))
+	}
+	for o, row := range l.W {
+		if len(l.GradW[o]) != len(row) {
+			l.GradW[o] = make([]float64, len(row))
+		}
+	}
+	if len(l.GradB) != len(l.B) {
+		l.GradB = make([]float64, len(l.B))
+	}
+}
@@ -190,3 +190,66 @@ func TestLinearLayer(t *testing.T) {
 		assert.InDelta(t, y[0], y[1], 1e-9)
 	})
 }
+
+func TestLinearLayerBackward(t *testing.T) {
+	t.Run("Backward returns W^T dy and accumulates outer product gradients", func(t *testing.T) {
+		// Arrange
+		ll := LinearLayer{
+			In:  2,
+			Out: 2,
+			W:   [][]float64{{1, 2}, {3, 4}},
+			B:   []float64{0, 0},
+		}
+		_, err := ll.Forward([]float64{5, 6})
+		assert.NoError(t, err)
+
+		// Act
+		dx, err := ll.Backward([]float64{1, -1})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{-2, -2}, dx, 1e-9)
+		assert.Equal(t, [][]float64{{5, 6}, {-5, -6}}, ll.GradW)
+		assert.Equal(t, []float64{1, -1}, ll.GradB)
+	})
+
+	t.Run("Backward accumulates across calls", func(t *testing.T) {
+		// Arrange
+		ll := LinearLayer{In: 1, Out: 1, W: [][]float64{{2}}}
+		_, err := ll.Forward([]float64{3})
+		assert.NoError(t, err)
+
+		// Act
+		_, err1 := ll.Backward([]float64{1})
+		_, err2 := ll.Backward([]float64{1})
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, 6.0, ll.GradW[0][0])
+	})
+
+	t.Run("Backward fails before Forward", func(t *testing.T) {
+		// Arrange
+		ll := LinearLayer{In: 1, Out: 1, W: [][]float64{{2}}}
+
+		// Act
+		_, err := ll.Backward([]float64{1})
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Backward fails when dy length mismatches Out", func(t *testing.T) {
+		// Arrange
+		ll := LinearLayer{In: 1, Out: 1, W: [][]float64{{2}}}
+		_, err := ll.Forward([]float64{3})
+		assert.NoError(t, err)
+
+		// Act
+		_, err = ll.Backward([]float64{1, 2})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,49 @@
+package network
+
+import (
+	"fmt"
+	"math"
+)
+
+// LogSumExp computes log(sum(exp(z))) without overflowing by factoring out the
+// max logit first.
+func LogSumExp(z []float64) (float64, error) {
+	if len(z) == 0 {
+		return 0, fmt.Errorf("input vector must have length greater than 0")
+	}
+
+	maxLogit := z[0]
+	for idx, el := range z {
+		if math.IsNaN(el) || math.IsInf(el, 0) {
+			return 0, fmt.Errorf("element %f position %d is invalid", el, idx)
+		}
+		if el > maxLogit {
+			maxLogit = el
+		}
+	}
+
+	sum := 0.0
+	for _, el := range z {
+		sum += math.Exp(el - maxLogit)
+	}
+
+	// sum is at least 1 because the max logit contributes exp(0).
+	return maxLogit + math.Log(sum), nil
+}
+
+// LogSoftmax returns log(softmax(z)) computed as z - LogSumExp(z). Unlike
+// taking the log of SoftmaxWithStats, very unlikely classes keep their exact
+// (very negative) log-probability rather than underflowing to log(0).
+func LogSoftmax(z []float64) ([]float64, error) {
+	lse, err := LogSumExp(z)
+	if err != nil {
+		return nil, fmt.Errorf("log softmax: %w", err)
+	}
+
+	output := make([]float64, len(z))
+	for idx, el := range z {
+		output[idx] = el - lse
+	}
+
+	return output, nil
+}
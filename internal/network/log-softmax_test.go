@@ -0,0 +1,108 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogSumExp(t *testing.T) {
+	t.Run("matches the naive computation for small logits", func(t *testing.T) {
+		// Arrange
+		z := []float64{1.0, 2.0, 3.0}
+		expected := math.Log(math.Exp(1) + math.Exp(2) + math.Exp(3))
+
+		// Act
+		got, err := LogSumExp(z)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, expected, got, 1e-12)
+	})
+
+	t.Run("does not overflow for very large logits", func(t *testing.T) {
+		// Arrange
+		z := []float64{1000, 1000}
+
+		// Act
+		got, err := LogSumExp(z)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 1000+math.Log(2), got, 1e-9)
+	})
+
+	t.Run("returns an error for an empty vector", func(t *testing.T) {
+		// Act
+		_, err := LogSumExp([]float64{})
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("returns an error for NaN or Inf elements", func(t *testing.T) {
+		// Act
+		_, errNaN := LogSumExp([]float64{1, math.NaN()})
+		_, errInf := LogSumExp([]float64{math.Inf(-1), 1})
+
+		// Assert
+		assert.Error(t, errNaN)
+		assert.Error(t, errInf)
+	})
+}
+
+func TestLogSoftmax(t *testing.T) {
+	t.Run("exponentiates back to SoftmaxWithStats", func(t *testing.T) {
+		// Arrange
+		z := []float64{1.4, 3.2, 8.8, 5.4}
+		p, _, err := SoftmaxWithStats(z)
+		assert.NoError(t, err)
+
+		// Act
+		logP, err := LogSoftmax(z)
+
+		// Assert
+		assert.NoError(t, err)
+		for i := range p {
+			assert.InDelta(t, p[i], math.Exp(logP[i]), 1e-12)
+		}
+	})
+
+	t.Run("keeps exact log-probabilities where softmax underflows", func(t *testing.T) {
+		// Arrange
+		z := []float64{0, -2000}
+
+		// Act
+		logP, err := LogSoftmax(z)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.0, logP[0], 1e-12)
+		assert.InDelta(t, -2000.0, logP[1], 1e-9)
+	})
+
+	t.Run("is shift-invariant", func(t *testing.T) {
+		// Arrange
+		z := []float64{0.3, -1.2, 2.5}
+		shifted, err := Shift(z, 123.4)
+		assert.NoError(t, err)
+
+		// Act
+		a, err1 := LogSoftmax(z)
+		b, err2 := LogSoftmax(shifted)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.InDeltaSlice(t, a, b, 1e-9)
+	})
+
+	t.Run("returns an error for NaN input", func(t *testing.T) {
+		// Act
+		_, err := LogSoftmax([]float64{math.NaN()})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,147 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// LSTMCell is the standard LSTM without peepholes:
+//
+//	i, f, o = σ(Input(x, h)), σ(Forget(x, h)), σ(Output(x, h))
+//	g = tanh(Cell(x, h))
+//	c' = f ⊙ c + i ⊙ g
+//	h' = o ⊙ tanh(c')
+//
+// The forget gate bias starts at 1 so early training keeps the cell state.
+type LSTMCell struct {
+	InputGate  Gate
+	ForgetGate Gate
+	CellGate   Gate
+	OutputGate Gate
+}
+
+type lstmCache struct {
+	x, h, c    []float64
+	i, f, g, o []float64
+	tanhC      []float64
+}
+
+func NewLSTMCell(in, hidden int, rng *rand.Rand) (*LSTMCell, error) {
+	var gates [4]Gate
+	for k := range gates {
+		gate, err := newGate(in, hidden, false, rng)
+		if err != nil {
+			return nil, errors.Join(errors.New("unable to create lstm gate"), err)
+		}
+		gates[k] = gate
+	}
+	for j := range gates[1].Input.B {
+		gates[1].Input.B[j] = 1
+	}
+
+	return &LSTMCell{InputGate: gates[0], ForgetGate: gates[1], CellGate: gates[2], OutputGate: gates[3]}, nil
+}
+
+func (c *LSTMCell) InputSize() int  { return c.InputGate.Input.In }
+func (c *LSTMCell) HiddenSize() int { return c.InputGate.Hidden.Out }
+
+func (c *LSTMCell) ZeroState() CellState {
+	return CellState{H: make([]float64, c.HiddenSize()), C: make([]float64, c.HiddenSize())}
+}
+
+func (c *LSTMCell) Step(x []float64, prev CellState) (CellState, any, error) {
+	if len(prev.C) != c.HiddenSize() {
+		return CellState{}, nil, fmt.Errorf("dimension mismatch: cell state has length %d, expected %d", len(prev.C), c.HiddenSize())
+	}
+
+	i, err := c.InputGate.preActivation(x, prev.H)
+	if err != nil {
+		return CellState{}, nil, err
+	}
+	f, err := c.ForgetGate.preActivation(x, prev.H)
+	if err != nil {
+		return CellState{}, nil, err
+	}
+	g, err := c.CellGate.preActivation(x, prev.H)
+	if err != nil {
+		return CellState{}, nil, err
+	}
+	o, err := c.OutputGate.preActivation(x, prev.H)
+	if err != nil {
+		return CellState{}, nil, err
+	}
+
+	size := c.HiddenSize()
+	next := CellState{H: make([]float64, size), C: make([]float64, size)}
+	tanhC := make([]float64, size)
+	for k := 0; k < size; k++ {
+		i[k] = sigmoid(i[k])
+		f[k] = sigmoid(f[k])
+		g[k] = math.Tanh(g[k])
+		o[k] = sigmoid(o[k])
+		next.C[k] = f[k]*prev.C[k] + i[k]*g[k]
+		tanhC[k] = math.Tanh(next.C[k])
+		next.H[k] = o[k] * tanhC[k]
+	}
+
+	cache := &lstmCache{
+		x: append([]float64(nil), x...), h: append([]float64(nil), prev.H...), c: append([]float64(nil), prev.C...),
+		i: i, f: f, g: g, o: o, tanhC: tanhC,
+	}
+
+	return next, cache, nil
+}
+
+// StepBackward treats a nil dNext.C as a zero gradient, which is what the last
+// step of a sequence receives.
+func (c *LSTMCell) StepBackward(cache any, dNext CellState) ([]float64, CellState, error) {
+	s, ok := cache.(*lstmCache)
+	if !ok {
+		return nil, CellState{}, fmt.Errorf("lstm cell given cache of type %T", cache)
+	}
+	size := len(s.h)
+	if len(dNext.H) != size {
+		return nil, CellState{}, fmt.Errorf("dimension mismatch: dh has length %d, expected %d", len(dNext.H), size)
+	}
+	if dNext.C != nil && len(dNext.C) != size {
+		return nil, CellState{}, fmt.Errorf("dimension mismatch: dc has length %d, expected %d", len(dNext.C), size)
+	}
+
+	dPrev := CellState{H: make([]float64, size), C: make([]float64, size)}
+	di := make([]float64, size)
+	df := make([]float64, size)
+	dg := make([]float64, size)
+	do := make([]float64, size)
+	for k, dh := range dNext.H {
+		dc := dh * s.o[k] * (1 - s.tanhC[k]*s.tanhC[k])
+		if dNext.C != nil {
+			dc += dNext.C[k]
+		}
+		dPrev.C[k] = dc * s.f[k]
+		di[k] = dc * s.g[k] * s.i[k] * (1 - s.i[k])
+		df[k] = dc * s.c[k] * s.f[k] * (1 - s.f[k])
+		dg[k] = dc * s.i[k] * (1 - s.g[k]*s.g[k])
+		do[k] = dh * s.tanhC[k] * s.o[k] * (1 - s.o[k])
+	}
+
+	dx := make([]float64, len(s.x))
+	for _, gate := range []struct {
+		gate *Gate
+		dPre []float64
+	}{{&c.InputGate, di}, {&c.ForgetGate, df}, {&c.CellGate, dg}, {&c.OutputGate, do}} {
+		if err := gate.gate.backward(s.x, s.h, gate.dPre, gate.dPre, dx, dPrev.H); err != nil {
+			return nil, CellState{}, err
+		}
+	}
+
+	return dx, dPrev, nil
+}
+
+func (c *LSTMCell) Params() []Param {
+	params := c.InputGate.params("input")
+	params = append(params, c.ForgetGate.params("forget")...)
+	params = append(params, c.CellGate.params("cell")...)
+	return append(params, c.OutputGate.params("output")...)
+}
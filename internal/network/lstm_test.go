@@ -0,0 +1,58 @@
+package network
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLSTMCell(t *testing.T) {
+	t.Run("gradients match finite differences", func(t *testing.T) {
+		// Arrange
+		r := newTestRecurrent(t, func(in, hidden int, rng *rand.Rand) (Cell, error) { return NewLSTMCell(in, hidden, rng) })
+
+		// Act
+		report, err := gradCheckSequence(r)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-5))
+	})
+
+	t.Run("initialises the forget gate bias to one", func(t *testing.T) {
+		// Act
+		cell, err := NewLSTMCell(2, 3, rand.New(rand.NewPCG(1, 1)))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{1, 1, 1}, cell.ForgetGate.Input.B)
+		assert.Equal(t, []float64{0, 0, 0}, cell.InputGate.Input.B)
+	})
+
+	t.Run("carries the cell state in the state", func(t *testing.T) {
+		// Arrange
+		cell, err := NewLSTMCell(2, 3, rand.New(rand.NewPCG(1, 1)))
+		assert.NoError(t, err)
+
+		// Act
+		next, _, err := cell.Step([]float64{0.5, -0.3}, cell.ZeroState())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, next.C, 3)
+		assert.NotEqual(t, make([]float64, 3), next.C)
+	})
+
+	t.Run("rejects a state without a cell state", func(t *testing.T) {
+		// Arrange
+		cell, err := NewLSTMCell(2, 3, rand.New(rand.NewPCG(1, 1)))
+		assert.NoError(t, err)
+
+		// Act
+		_, _, err = cell.Step([]float64{0.5, -0.3}, CellState{H: make([]float64, 3)})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
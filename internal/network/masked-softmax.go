@@ -0,0 +1,68 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrAllMasked is returned by MaskedSoftmax when every position is masked, as
+// there is no distribution to normalise. Callers that want a defined output
+// for such rows (e.g. fully padded sequences) can check for it with errors.Is
+// and substitute zeros.
+var ErrAllMasked = errors.New("softmax: every position is masked")
+
+// MaskedSoftmax is SoftmaxWithStats over the positions where mask is false.
+// Masked positions get a probability of exactly 0 and may hold any value,
+// including -Inf; unmasked positions are validated as in SoftmaxWithStats.
+// The stats describe the unmasked entries only.
+func MaskedSoftmax(z []float64, mask []bool) ([]float64, SoftmaxStats, error) {
+	if len(z) != len(mask) {
+		return nil, SoftmaxStats{}, fmt.Errorf("dimension mismatch: z has length %d, mask has length %d", len(z), len(mask))
+	}
+	if len(z) == 0 {
+		return nil, SoftmaxStats{}, fmt.Errorf("input vector must have length greater than 0")
+	}
+
+	kept := make([]float64, 0, len(z))
+	for idx, el := range z {
+		if mask[idx] {
+			continue
+		}
+		if math.IsNaN(el) || math.IsInf(el, 0) {
+			return nil, SoftmaxStats{}, fmt.Errorf("element %f position %d is invalid", el, idx)
+		}
+		kept = append(kept, el)
+	}
+	if len(kept) == 0 {
+		return nil, SoftmaxStats{}, ErrAllMasked
+	}
+
+	p, stats, err := SoftmaxWithStats(kept)
+	if err != nil {
+		return nil, SoftmaxStats{}, err
+	}
+
+	probabilities := make([]float64, len(z))
+	next := 0
+	for idx := range z {
+		if mask[idx] {
+			continue
+		}
+		probabilities[idx] = p[next]
+		next++
+	}
+
+	return probabilities, stats, nil
+}
+
+// CausalMask returns the mask for position t of a length-n sequence, masking
+// every later position.
+func CausalMask(t, n int) []bool {
+	mask := make([]bool, n)
+	for s := t + 1; s < n; s++ {
+		mask[s] = true
+	}
+
+	return mask
+}
@@ -0,0 +1,69 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskedSoftmax(t *testing.T) {
+	t.Run("masked positions are exactly zero", func(t *testing.T) {
+		// Arrange
+		z := []float64{1, math.Inf(-1), 0, 5}
+		mask := []bool{false, true, false, true}
+
+		// Act
+		p, stats, err := MaskedSoftmax(z, mask)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, p[1])
+		assert.Equal(t, 0.0, p[3])
+		e := math.E / (math.E + 1)
+		assert.InDeltaSlice(t, []float64{e, 0, 1 - e, 0}, p, 1e-12)
+		assert.Equal(t, 1.0, stats.MaxLogit)
+		assert.InDelta(t, 1-e, stats.MinProb, 1e-12)
+	})
+
+	t.Run("matches SoftmaxWithStats on the unmasked entries", func(t *testing.T) {
+		// Arrange
+		z := []float64{0.3, -2, 1.7}
+		want, wantStats, err := SoftmaxWithStats([]float64{0.3, 1.7})
+		assert.NoError(t, err)
+
+		// Act
+		p, stats, err := MaskedSoftmax(z, []bool{false, true, false})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{want[0], 0, want[1]}, p)
+		assert.Equal(t, wantStats, stats)
+	})
+
+	t.Run("all-masked rows return ErrAllMasked", func(t *testing.T) {
+		// Act
+		_, _, err := MaskedSoftmax([]float64{1, 2}, []bool{true, true})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrAllMasked)
+	})
+
+	t.Run("rejects invalid unmasked entries and length mismatches", func(t *testing.T) {
+		// Act
+		_, _, errInf := MaskedSoftmax([]float64{math.Inf(-1), 0}, []bool{false, false})
+		_, _, errLen := MaskedSoftmax([]float64{0, 0}, []bool{false})
+
+		// Assert
+		assert.Error(t, errInf)
+		assert.Error(t, errLen)
+	})
+
+	t.Run("causal mask hides later positions", func(t *testing.T) {
+		// Act
+		mask := CausalMask(1, 4)
+
+		// Assert
+		assert.Equal(t, []bool{false, false, true, true}, mask)
+	})
+}
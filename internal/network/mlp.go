@@ -13,7 +13,7 @@ type MLP struct {
 }
 
 // Forward passes the MLP to pass Hidden block results to the output layer.
-func (m MLP) Forward(x []float64) ([]float64, error) {
+func (m *MLP) Forward(x []float64) ([]float64, error) {
 	if err := m.Validate(); err != nil {
 		return nil, err
 	}
@@ -31,9 +31,78 @@ func (m MLP) Forward(x []float64) ([]float64, error) {
 	return z, nil
 }
 
+// Backward propagates the gradient of the logits back through the output layer
+// and the hidden block, returning the gradient with respect to the input.
+func (m *MLP) Backward(dz []float64) ([]float64, error) {
+	da, err := m.Out.Backward(dz)
+	if err != nil {
+		return nil, errors.Join(errors.New("MLP unable to backward output layer"), err)
+	}
+
+	dx, err := m.Hidden.Backward(da)
+	if err != nil {
+		return nil, errors.Join(errors.New("MLP unable to backward block"), err)
+	}
+
+	return dx, nil
+}
+
+// ForwardBatch is Forward over the rows of X. Layers that need batch
+// statistics, such as BatchNorm1d in the hidden block, can only be trained
+// through ForwardBatch and BackwardBatch.
+func (m *MLP) ForwardBatch(X [][]float64) ([][]float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	A, err := m.Hidden.ForwardBatch(X)
+	if err != nil {
+		return nil, errors.Join(errors.New("MLP unable to forward block batch"), err)
+	}
+
+	Z, err := m.Out.ForwardBatch(A)
+	if err != nil {
+		return nil, errors.Join(errors.New("MLP unable to forward output layer batch"), err)
+	}
+
+	return Z, nil
+}
+
+// BackwardBatch propagates the gradients of the logits of the last
+// ForwardBatch, accumulating parameter gradients over the rows.
+func (m *MLP) BackwardBatch(dZ [][]float64) ([][]float64, error) {
+	dA, err := m.Out.BackwardBatch(dZ)
+	if err != nil {
+		return nil, errors.Join(errors.New("MLP unable to backward output layer batch"), err)
+	}
+
+	dX, err := m.Hidden.BackwardBatch(dA)
+	if err != nil {
+		return nil, errors.Join(errors.New("MLP unable to backward block batch"), err)
+	}
+
+	return dX, nil
+}
+
+func (m *MLP) Params() []Param {
+	return append(prefixParams("hidden", m.Hidden.Params()), prefixParams("out", m.Out.Params())...)
+}
+
+// SetTraining switches the MLP between training and evaluation. In
+// evaluation Forward is deterministic. Dropout on the hidden activations is
+// configured through Hidden.Dropout.
+func (m *MLP) SetTraining(training bool) {
+	m.Hidden.SetTraining(training)
+}
+
+// Training reports whether the MLP is in training mode.
+func (m *MLP) Training() bool {
+	return m.Hidden.Training()
+}
+
 // Validate ensures that the Hidden blocks output is the same size as the
 // Output layers input.
-func (m MLP) Validate() error {
+func (m *MLP) Validate() error {
 	if err := m.Hidden.Validate(); err != nil {
 		return errors.Join(errors.New("MLP failed to validate hidden block"), err)
 	}
@@ -112,4 +112,107 @@ func TestMLP(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("MLP Backward gradients match finite differences", func(t *testing.T) {
+		// Arrange
+		mlp := &MLP{
+			Hidden: Block{
+				LinearLayer: LinearLayer{
+					In:  3,
+					Out: 4,
+					W:   [][]float64{{0.4, -0.2, 0.1}, {-0.1, 0.2, 0.3}, {0.5, 0.3, -0.4}, {0.2, -0.6, 0.1}},
+					B:   []float64{0.1, 0.2, -0.1, 0.05},
+				},
+				Nonlinearity: ReLU{},
+			},
+			Out: LinearLayer{
+				In:  4,
+				Out: 3,
+				W:   [][]float64{{0.4, 0.2, -0.1, 0.4}, {-0.1, 0.2, 0.3, 0.2}, {0.4, -0.2, 0.1, 0.5}},
+				B:   []float64{0.4, 0.7, 1.2},
+			},
+		}
+
+		// Act
+		report, err := GradCheck(mlp, []float64{0.9, -0.8, 0.3}, GradCheckConfig{Seed: 42})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-6))
+	})
+
+	t.Run("MLP with BatchNorm1d trains through batch passes", func(t *testing.T) {
+		// Arrange
+		bn, err := NewBatchNorm1d(4)
+		assert.NoError(t, err)
+		mlp := &MLP{
+			Hidden: Block{
+				LinearLayer: LinearLayer{
+					In:  2,
+					Out: 4,
+					W:   [][]float64{{0.4, -0.2}, {-0.1, 0.3}, {0.5, 0.3}, {0.2, -0.6}},
+					B:   []float64{0.1, 0.2, -0.1, 0.05},
+				},
+				Norm:         bn,
+				Nonlinearity: ReLU{},
+			},
+			Out: LinearLayer{
+				In:  4,
+				Out: 2,
+				W:   [][]float64{{0.4, 0.2, -0.1, 0.4}, {-0.1, 0.2, 0.3, 0.2}},
+				B:   []float64{0, 0},
+			},
+		}
+		X := [][]float64{{1, 0.5}, {-1, 0.2}, {0.8, -0.9}, {-0.7, -0.4}}
+		targets := []int{0, 1, 0, 1}
+		opt := &Adam{LearningRate: 0.05}
+
+		step := func() float64 {
+			ZeroGrad(mlp.Params())
+			Z, err := mlp.ForwardBatch(X)
+			assert.NoError(t, err)
+			res, err := BatchCrossEntropy(Z, targets, CrossEntropyOptions{})
+			assert.NoError(t, err)
+			_, err = mlp.BackwardBatch(res.Grad)
+			assert.NoError(t, err)
+			assert.NoError(t, opt.Step(mlp.Params()))
+			return res.Loss
+		}
+
+		// Act
+		first := step()
+		last := first
+		for range 50 {
+			last = step()
+		}
+
+		// Assert
+		assert.Less(t, last, first/2)
+		_, err = mlp.Forward(X[0])
+		assert.Error(t, err, "single samples need evaluation mode")
+		mlp.SetTraining(false)
+		z, err := mlp.Forward(X[0])
+		assert.NoError(t, err)
+		assert.Len(t, z, 2)
+	})
+
+	t.Run("MLP BackwardBatch gradients match finite differences", func(t *testing.T) {
+		// Arrange
+		bn, err := NewBatchNorm1d(3)
+		assert.NoError(t, err)
+		mlp := &MLP{
+			Hidden: Block{
+				LinearLayer:  LinearLayer{In: 2, Out: 3, W: [][]float64{{0.5, -0.3}, {0.2, 0.8}, {-0.6, 0.4}}, B: []float64{0.1, 0.2, 0.3}},
+				Norm:         bn,
+				Nonlinearity: ReLU{},
+			},
+			Out: LinearLayer{In: 3, Out: 2, W: [][]float64{{0.3, -0.2, 0.5}, {0.1, 0.4, -0.3}}, B: []float64{0.2, -0.1}},
+		}
+
+		// Act
+		report, err := gradCheckBatch(mlp, [][]float64{{1.1, 0.7}, {-0.4, 0.9}, {0.3, -1.2}, {2.0, 0.1}})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-5))
+	})
 }
@@ -0,0 +1,34 @@
+package network
+
+import (
+	"fmt"
+	"math"
+)
+
+// NLLLoss is the negative log-likelihood of the target class given a vector of
+// log-probabilities, such as the output of LogSoftmax. No clamping is applied
+// so the loss stays exact however unlikely the target class is.
+func NLLLoss(logProbs []float64, target int) (float64, error) {
+	if len(logProbs) == 0 {
+		return 0, fmt.Errorf("logProbs must have length")
+	}
+	if target < 0 || target >= len(logProbs) {
+		return 0, fmt.Errorf("target %d out of range for %d classes", target, len(logProbs))
+	}
+
+	for i, lp := range logProbs {
+		if math.IsNaN(lp) || math.IsInf(lp, 0) {
+			return 0, fmt.Errorf("logProbs[%d] is NaN/Inf", i)
+		}
+	}
+
+	lse, err := LogSumExp(logProbs)
+	if err != nil {
+		return 0, fmt.Errorf("nll loss: %w", err)
+	}
+	if math.Abs(lse) > 1e-6 {
+		return 0, fmt.Errorf("logProbs must be normalised (logsumexp 0), got %v", lse)
+	}
+
+	return -logProbs[target], nil
+}
@@ -0,0 +1,80 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNLLLoss(t *testing.T) {
+	t.Run("returns -logProbs[target]", func(t *testing.T) {
+		// Arrange
+		logP := []float64{math.Log(0.7), math.Log(0.2), math.Log(0.1)}
+
+		// Act
+		loss, err := NLLLoss(logP, 1)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, -math.Log(0.2), loss, 1e-12)
+	})
+
+	t.Run("agrees with CrossEntropy on softmax probabilities", func(t *testing.T) {
+		// Arrange
+		z := []float64{0.5, 2.1, -0.3}
+		p, _, err := SoftmaxWithStats(z)
+		assert.NoError(t, err)
+		logP, err := LogSoftmax(z)
+		assert.NoError(t, err)
+
+		// Act
+		ce, err1 := CrossEntropy([]float64{0, 0, 1}, p)
+		nll, err2 := NLLLoss(logP, 2)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.InDelta(t, ce, nll, 1e-12)
+	})
+
+	t.Run("is not clamped for very negative log-probabilities", func(t *testing.T) {
+		// Arrange
+		logP, err := LogSoftmax([]float64{0, -100})
+		assert.NoError(t, err)
+
+		// Act
+		loss, err := NLLLoss(logP, 1)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Greater(t, loss, -math.Log(1e-15))
+		assert.InDelta(t, 100.0, loss, 1e-9)
+	})
+
+	t.Run("returns an error for an out of range target", func(t *testing.T) {
+		// Act
+		_, errLow := NLLLoss([]float64{0}, -1)
+		_, errHigh := NLLLoss([]float64{0}, 1)
+
+		// Assert
+		assert.Error(t, errLow)
+		assert.Error(t, errHigh)
+	})
+
+	t.Run("returns an error for NaN/Inf log-probabilities", func(t *testing.T) {
+		// Act
+		_, err := NLLLoss([]float64{0, math.Inf(-1)}, 0)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("returns an error if log-probabilities are not normalised", func(t *testing.T) {
+		// Act
+		_, err := NLLLoss([]float64{-0.1, -0.2}, 0)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
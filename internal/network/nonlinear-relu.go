@@ -17,3 +17,20 @@ func (r ReLU) Apply(v []float64) ([]float64, error) {
 
 	return output, nil
 }
+
+// Backward passes dy through where v was positive and blocks it elsewhere.
+func (r ReLU) Backward(v, dy []float64) ([]float64, error) {
+	if len(v) != len(dy) {
+		return nil, fmt.Errorf("dimension mismatch: v has length %d, dy has length %d", len(v), len(dy))
+	}
+
+	output := make([]float64, len(v))
+
+	for idx, el := range v {
+		if el > 0 {
+			output[idx] = dy[idx]
+		}
+	}
+
+	return output, nil
+}
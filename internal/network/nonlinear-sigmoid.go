@@ -0,0 +1,35 @@
+package network
+
+import "fmt"
+
+type Sigmoid struct{}
+
+func (s Sigmoid) Apply(v []float64) ([]float64, error) {
+	if len(v) == 0 {
+		return nil, fmt.Errorf("v must not be length 0")
+	}
+
+	output := make([]float64, len(v))
+
+	for idx, el := range v {
+		output[idx] = sigmoid(el)
+	}
+
+	return output, nil
+}
+
+// Backward scales dy by σ(v)(1 - σ(v)).
+func (s Sigmoid) Backward(v, dy []float64) ([]float64, error) {
+	if len(v) != len(dy) {
+		return nil, fmt.Errorf("dimension mismatch: v has length %d, dy has length %d", len(v), len(dy))
+	}
+
+	output := make([]float64, len(v))
+
+	for idx, el := range v {
+		y := sigmoid(el)
+		output[idx] = dy[idx] * y * (1 - y)
+	}
+
+	return output, nil
+}
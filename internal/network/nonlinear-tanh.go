@@ -0,0 +1,38 @@
+package network
+
+import (
+	"fmt"
+	"math"
+)
+
+type Tanh struct{}
+
+func (th Tanh) Apply(v []float64) ([]float64, error) {
+	if len(v) == 0 {
+		return nil, fmt.Errorf("v must not be length 0")
+	}
+
+	output := make([]float64, len(v))
+
+	for idx, el := range v {
+		output[idx] = math.Tanh(el)
+	}
+
+	return output, nil
+}
+
+// Backward scales dy by 1 - tanh(v)².
+func (th Tanh) Backward(v, dy []float64) ([]float64, error) {
+	if len(v) != len(dy) {
+		return nil, fmt.Errorf("dimension mismatch: v has length %d, dy has length %d", len(v), len(dy))
+	}
+
+	output := make([]float64, len(v))
+
+	for idx, el := range v {
+		y := math.Tanh(el)
+		output[idx] = dy[idx] * (1 - y*y)
+	}
+
+	return output, nil
+}
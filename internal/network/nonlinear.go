@@ -11,6 +11,9 @@ type Nonlinearity interface {
 	// Function that applies element wise to a vector. The result will be of
 	// same length and exists to transform the linear layer with some curvature.
 	Apply(v []float64) ([]float64, error)
+	// Backward maps the gradient dy with respect to Apply(v) to the gradient
+	// with respect to v.
+	Backward(v, dy []float64) ([]float64, error)
 }
 
 // The NonLinearityContract is to create a common testing of the interface as
@@ -69,4 +72,54 @@ func (n *NonLinearityContract) Test(t *testing.T) {
 		assert.InDeltaSlice(t, res1, res2, 1e-9)
 	})
 
+	t.Run("Backward returns a gradient of same length as input vector", func(t *testing.T) {
+		// Arrange
+		input := []float64{2.1, -3.4, 2.2}
+		dy := []float64{1.0, 1.0, 1.0}
+
+		// Act
+		result, err := n.Nl.Backward(input, dy)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, len(input), len(result))
+	})
+
+	t.Run("Backward returns an error if dy length differs from input", func(t *testing.T) {
+		// Arrange
+		input := []float64{2.1, 3.4, 2.2}
+		dy := []float64{1.0}
+
+		// Act
+		_, err := n.Nl.Backward(input, dy)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Backward matches central differences of Apply", func(t *testing.T) {
+		// Arrange
+		// Inputs avoid 0 where piecewise functions such as ReLU have a kink.
+		input := []float64{1.3, -0.7, 0.4, -2.2}
+		dy := []float64{0.5, -1.0, 2.0, 1.5}
+		const h = 1e-6
+
+		// Act
+		grad, err := n.Nl.Backward(input, dy)
+		assert.NoError(t, err)
+
+		// Assert
+		for i := range input {
+			plus := append([]float64(nil), input...)
+			minus := append([]float64(nil), input...)
+			plus[i] += h
+			minus[i] -= h
+			yp, err1 := n.Nl.Apply(plus)
+			ym, err2 := n.Nl.Apply(minus)
+			assert.NoError(t, err1)
+			assert.NoError(t, err2)
+			assert.InDelta(t, dy[i]*(yp[i]-ym[i])/(2*h), grad[i], 1e-6)
+		}
+	})
+
 }
@@ -12,6 +12,14 @@ func TestNonLinearContract(t *testing.T) {
 		nlc := NewNonlinearityContract(ReLU{})
 		nlc.Test(t)
 	})
+	t.Run("Test Tanh", func(t *testing.T) {
+		nlc := NewNonlinearityContract(Tanh{})
+		nlc.Test(t)
+	})
+	t.Run("Test Sigmoid", func(t *testing.T) {
+		nlc := NewNonlinearityContract(Sigmoid{})
+		nlc.Test(t)
+	})
 }
 
 func TestReLU(t *testing.T) {
@@ -54,3 +62,25 @@ func TestReLU(t *testing.T) {
 		assert.NotEqual(t, reluUPlusV, reluUPlusReluV)
 	})
 }
+
+func TestTanh(t *testing.T) {
+	t.Run("Apply is odd and bounded by 1", func(t *testing.T) {
+		// Act
+		result, err := Tanh{}.Apply([]float64{-30, -0.5, 0, 0.5, 30})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{-1, -0.46211715726, 0, 0.46211715726, 1}, result, 1e-9)
+	})
+}
+
+func TestSigmoid(t *testing.T) {
+	t.Run("Apply maps to (0, 1) with σ(0) = 0.5", func(t *testing.T) {
+		// Act
+		result, err := Sigmoid{}.Apply([]float64{-800, 0, 800})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{0, 0.5, 1}, result, 1e-12)
+	})
+}
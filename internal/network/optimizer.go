@@ -0,0 +1,179 @@
+package network
+
+import (
+	"fmt"
+	"math"
+)
+
+// Optimizer updates params in place from their accumulated gradients.
+type Optimizer interface {
+	Step(params []Param) error
+}
+
+// SGD is plain stochastic gradient descent.
+type SGD struct {
+	LearningRate float64
+	// WeightDecay shrinks the selected params by LearningRate * WeightDecay * w
+	// each step, decoupled from the gradient.
+	WeightDecay float64
+	DecayFilter ParamFilter
+}
+
+func (o *SGD) Step(params []Param) error {
+	if err := validateStep(o.LearningRate, o.WeightDecay, params); err != nil {
+		return err
+	}
+
+	for _, p := range params {
+		decay := o.WeightDecay
+		if o.DecayFilter != nil && !o.DecayFilter(p) {
+			decay = 0
+		}
+
+		for i, row := range p.Value {
+			for j := range row {
+				row[j] -= o.LearningRate * (p.Grad[i][j] + decay*row[j])
+			}
+		}
+	}
+
+	return nil
+}
+
+// Adam is the Adam optimizer. With WeightDecay set it becomes AdamW: the decay
+// is applied directly to the weights rather than added to the gradient, so it
+// is not rescaled by the adaptive step size. State, including the step count
+// used for bias correction, is kept per param and keyed by name, so params
+// may be stepped in separate groups or join partway through training.
+type Adam struct {
+	LearningRate float64
+	// Beta1, Beta2 and Epsilon default to 0.9, 0.999 and 1e-8 when zero.
+	Beta1       float64
+	Beta2       float64
+	Epsilon     float64
+	WeightDecay float64
+	DecayFilter ParamFilter
+
+	steps map[string]int
+	m     map[string][][]float64
+	v     map[string][][]float64
+}
+
+func (o *Adam) Step(params []Param) error {
+	if err := validateStep(o.LearningRate, o.WeightDecay, params); err != nil {
+		return err
+	}
+
+	beta1, beta2, eps := o.Beta1, o.Beta2, o.Epsilon
+	if beta1 == 0 {
+		beta1 = 0.9
+	}
+	if beta2 == 0 {
+		beta2 = 0.999
+	}
+	if eps == 0 {
+		eps = 1e-8
+	}
+	if o.m == nil {
+		o.steps = make(map[string]int)
+		o.m = make(map[string][][]float64)
+		o.v = make(map[string][][]float64)
+	}
+	if err := o.checkState(params); err != nil {
+		return err
+	}
+
+	for _, p := range params {
+		m, v := o.moments(p)
+		o.steps[p.Name]++
+		correction1 := 1 - math.Pow(beta1, float64(o.steps[p.Name]))
+		correction2 := 1 - math.Pow(beta2, float64(o.steps[p.Name]))
+		decay := o.WeightDecay
+		if o.DecayFilter != nil && !o.DecayFilter(p) {
+			decay = 0
+		}
+
+		for i, row := range p.Value {
+			for j := range row {
+				g := p.Grad[i][j]
+				m[i][j] = beta1*m[i][j] + (1-beta1)*g
+				v[i][j] = beta2*v[i][j] + (1-beta2)*g*g
+
+				mHat := m[i][j] / correction1
+				vHat := v[i][j] / correction2
+				row[j] -= o.LearningRate * (mHat/(math.Sqrt(vHat)+eps) + decay*row[j])
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkState ensures every param maps to its own moment buffers: names must
+// be unique within a step and match the shape stored for them on earlier
+// steps. Params from separate layers should be prefixed (e.g. with
+// prefixParams) so their names do not collide.
+func (o *Adam) checkState(params []Param) error {
+	seen := make(map[string]bool, len(params))
+	for _, p := range params {
+		if seen[p.Name] {
+			return fmt.Errorf("duplicate param name %q; Adam keys its state by name", p.Name)
+		}
+		seen[p.Name] = true
+
+		m, ok := o.m[p.Name]
+		if !ok {
+			continue
+		}
+		if len(m) != len(p.Value) {
+			return fmt.Errorf("param %s has %d rows, Adam state has %d", p.Name, len(p.Value), len(m))
+		}
+		for i, row := range p.Value {
+			if len(m[i]) != len(row) {
+				return fmt.Errorf("param %s row %d has %d values, Adam state has %d", p.Name, i, len(row), len(m[i]))
+			}
+		}
+	}
+
+	return nil
+}
+
+// moments returns the first and second moment buffers for p, allocating them
+// to match p's shape on first use.
+func (o *Adam) moments(p Param) ([][]float64, [][]float64) {
+	m, ok := o.m[p.Name]
+	if !ok {
+		m = make([][]float64, len(p.Value))
+		v := make([][]float64, len(p.Value))
+		for i, row := range p.Value {
+			m[i] = make([]float64, len(row))
+			v[i] = make([]float64, len(row))
+		}
+		o.m[p.Name] = m
+		o.v[p.Name] = v
+	}
+
+	return m, o.v[p.Name]
+}
+
+func validateStep(lr, decay float64, params []Param) error {
+	if lr <= 0 || math.IsNaN(lr) || math.IsInf(lr, 0) {
+		return fmt.Errorf("learning rate must be positive and finite, got %v", lr)
+	}
+	if decay < 0 || math.IsNaN(decay) {
+		return fmt.Errorf("weight decay must be non-negative, got %v", decay)
+	}
+
+	for _, p := range params {
+		if len(p.Grad) != len(p.Value) {
+			return fmt.Errorf("param %s has %d value rows and %d grad rows", p.Name, len(p.Value), len(p.Grad))
+		}
+		for i, row := range p.Value {
+			if len(p.Grad[i]) != len(row) {
+				return fmt.Errorf("param %s row %d has %d values and %d grads", p.Name, i, len(row), len(p.Grad[i]))
+			}
+		}
+	}
+
+	return nil
+}
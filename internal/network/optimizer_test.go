@@ -0,0 +1,145 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSGD(t *testing.T) {
+	t.Run("steps against the gradient", func(t *testing.T) {
+		// Arrange
+		params := []Param{{Name: "W", Value: [][]float64{{1, 2}}, Grad: [][]float64{{0.5, -1}}}}
+		opt := &SGD{LearningRate: 0.1}
+
+		// Act
+		err := opt.Step(params)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{0.95, 2.1}, params[0].Value[0], 1e-12)
+	})
+
+	t.Run("decoupled weight decay shrinks selected params only", func(t *testing.T) {
+		// Arrange
+		ll := &LinearLayer{In: 1, Out: 1, W: [][]float64{{2}}, B: []float64{2}}
+		opt := &SGD{LearningRate: 0.1, WeightDecay: 0.5, DecayFilter: WeightsOnly}
+
+		// Act
+		err := opt.Step(ll.Params())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 1.9, ll.W[0][0], 1e-12)
+		assert.InDelta(t, 2.0, ll.B[0], 1e-12)
+	})
+
+	t.Run("returns an error for an invalid learning rate", func(t *testing.T) {
+		// Act
+		err := (&SGD{}).Step(nil)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestAdam(t *testing.T) {
+	t.Run("first step moves each param by the learning rate", func(t *testing.T) {
+		// Arrange
+		params := []Param{{Name: "W", Value: [][]float64{{1, 1}}, Grad: [][]float64{{100, -0.01}}}}
+		opt := &Adam{LearningRate: 0.01}
+
+		// Act
+		err := opt.Step(params)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{0.99, 1.01}, params[0].Value[0], 1e-6)
+	})
+
+	t.Run("weight decay is not rescaled by the adaptive step", func(t *testing.T) {
+		// Arrange
+		decayed := []Param{{Name: "W", Value: [][]float64{{2}}, Grad: [][]float64{{1}}}}
+		plain := []Param{{Name: "W", Value: [][]float64{{2}}, Grad: [][]float64{{1}}}}
+
+		// Act
+		err1 := (&Adam{LearningRate: 0.1, WeightDecay: 0.5}).Step(decayed)
+		err2 := (&Adam{LearningRate: 0.1}).Step(plain)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.InDelta(t, 0.1*0.5*2, plain[0].Value[0][0]-decayed[0].Value[0][0], 1e-9)
+	})
+
+	t.Run("minimises a quadratic", func(t *testing.T) {
+		// Arrange
+		// loss = (w - 3)^2
+		params := []Param{{Name: "w", Value: [][]float64{{0}}, Grad: [][]float64{{0}}}}
+		opt := &Adam{LearningRate: 0.1}
+
+		// Act
+		for range 500 {
+			params[0].Grad[0][0] = 2 * (params[0].Value[0][0] - 3)
+			assert.NoError(t, opt.Step(params))
+		}
+
+		// Assert
+		assert.InDelta(t, 3.0, params[0].Value[0][0], 1e-3)
+	})
+
+	t.Run("keeps a step count per param", func(t *testing.T) {
+		// Arrange
+		opt := &Adam{LearningRate: 0.01}
+		early := []Param{{Name: "early", Value: [][]float64{{0}}, Grad: [][]float64{{1}}}}
+		late := []Param{{Name: "late", Value: [][]float64{{1}}, Grad: [][]float64{{100}}}}
+		for range 10 {
+			assert.NoError(t, opt.Step(early))
+		}
+
+		// Act: the late param joins in its own group after ten steps.
+		err := opt.Step(late)
+
+		// Assert: its first update is fully bias-corrected.
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.99, late[0].Value[0][0], 1e-6)
+	})
+
+	t.Run("returns an error for mismatched grad shapes", func(t *testing.T) {
+		// Arrange
+		params := []Param{{Name: "W", Value: [][]float64{{1, 2}}, Grad: [][]float64{{1}}}}
+
+		// Act
+		err := (&Adam{LearningRate: 0.1}).Step(params)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("returns an error for duplicate param names", func(t *testing.T) {
+		// Arrange
+		a := &LinearLayer{In: 2, Out: 2, W: [][]float64{{1, 2}, {3, 4}}, B: []float64{0, 0}}
+		b := &LinearLayer{In: 3, Out: 1, W: [][]float64{{1, 2, 3}}, B: []float64{0}}
+		params := append(a.Params(), b.Params()...)
+		before := cloneMatrix(a.W)
+
+		// Act
+		err := (&Adam{LearningRate: 0.1}).Step(params)
+
+		// Assert
+		assert.ErrorContains(t, err, "duplicate param name")
+		assert.Equal(t, before, a.W)
+	})
+
+	t.Run("returns an error when a param changes shape between steps", func(t *testing.T) {
+		// Arrange
+		opt := &Adam{LearningRate: 0.1}
+		assert.NoError(t, opt.Step([]Param{{Name: "W", Value: [][]float64{{1, 2}}, Grad: [][]float64{{1, 1}}}}))
+
+		// Act
+		err := opt.Step([]Param{{Name: "W", Value: [][]float64{{1, 2, 3}}, Grad: [][]float64{{1, 1, 1}}}})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,198 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ParamStats summarises one parameter (e.g. "hidden.linear.W") at one
+// training step.
+type ParamStats struct {
+	Step         int     `json:"step"`
+	Name         string  `json:"param"`
+	WeightNorm   float64 `json:"weight_norm"`
+	GradNorm     float64 `json:"grad_norm"`
+	UpdateRatio  float64 `json:"update_ratio"` // ||after - before|| / ||before||
+	MaxAbsWeight float64 `json:"max_abs_weight"`
+	MaxAbsGrad   float64 `json:"max_abs_grad"`
+}
+
+// ParamStatsCollector records ParamStats per step. A training loop calls
+// BeforeStep after backward and before the optimizer updates the params, then
+// AfterStep once the update is applied.
+type ParamStatsCollector struct {
+	before  map[string][][]float64
+	records []ParamStats
+}
+
+func NewParamStatsCollector() *ParamStatsCollector {
+	return &ParamStatsCollector{before: make(map[string][][]float64)}
+}
+
+// BeforeStep snapshots the parameter values so AfterStep can measure the size
+// of the update.
+func (c *ParamStatsCollector) BeforeStep(params []Param) {
+	clear(c.before)
+	for _, p := range params {
+		snapshot := make([][]float64, len(p.Value))
+		for r, row := range p.Value {
+			snapshot[r] = append([]float64(nil), row...)
+		}
+		c.before[p.Name] = snapshot
+	}
+}
+
+// AfterStep records and returns the stats of each param for step. Gradients
+// are read as they are, so call it before zeroing them. Without a matching
+// BeforeStep the update ratio is left at 0.
+func (c *ParamStatsCollector) AfterStep(step int, params []Param) ([]ParamStats, error) {
+	stats := make([]ParamStats, 0, len(params))
+
+	for _, p := range params {
+		s := ParamStats{Step: step, Name: p.Name}
+		weightSq, gradSq, updateSq, beforeSq := 0.0, 0.0, 0.0, 0.0
+
+		before, hasBefore := c.before[p.Name]
+		if hasBefore && len(before) != len(p.Value) {
+			return nil, fmt.Errorf("param %s changed shape between BeforeStep and AfterStep", p.Name)
+		}
+		if len(p.Grad) != len(p.Value) {
+			return nil, fmt.Errorf("param %s has %d value rows and %d grad rows", p.Name, len(p.Value), len(p.Grad))
+		}
+
+		for r, row := range p.Value {
+			if len(p.Grad[r]) != len(row) || (hasBefore && len(before[r]) != len(row)) {
+				return nil, fmt.Errorf("param %s row %d has mismatched lengths", p.Name, r)
+			}
+
+			for i, w := range row {
+				g := p.Grad[r][i]
+				weightSq += w * w
+				gradSq += g * g
+				s.MaxAbsWeight = max(s.MaxAbsWeight, math.Abs(w))
+				s.MaxAbsGrad = max(s.MaxAbsGrad, math.Abs(g))
+
+				if hasBefore {
+					d := w - before[r][i]
+					updateSq += d * d
+					beforeSq += before[r][i] * before[r][i]
+				}
+			}
+		}
+
+		s.WeightNorm = math.Sqrt(weightSq)
+		s.GradNorm = math.Sqrt(gradSq)
+		if beforeSq > 0 {
+			s.UpdateRatio = math.Sqrt(updateSq / beforeSq)
+		}
+
+		stats = append(stats, s)
+	}
+
+	c.records = append(c.records, stats...)
+
+	return stats, nil
+}
+
+// Records returns every ParamStats recorded so far in step order.
+func (c *ParamStatsCollector) Records() []ParamStats {
+	return c.records
+}
+
+// MarshalJSON encodes non-finite values, which encoding/json rejects, as the
+// strings "NaN", "+Inf" and "-Inf", and sets "finite" to false when any value
+// is non-finite, so diverging runs can still be exported.
+func (s ParamStats) MarshalJSON() ([]byte, error) {
+	values := []float64{s.WeightNorm, s.GradNorm, s.UpdateRatio, s.MaxAbsWeight, s.MaxAbsGrad}
+	finite := true
+	for _, v := range values {
+		finite = finite && !math.IsNaN(v) && !math.IsInf(v, 0)
+	}
+
+	return json.Marshal(struct {
+		Step         int       `json:"step"`
+		Name         string    `json:"param"`
+		WeightNorm   jsonFloat `json:"weight_norm"`
+		GradNorm     jsonFloat `json:"grad_norm"`
+		UpdateRatio  jsonFloat `json:"update_ratio"`
+		MaxAbsWeight jsonFloat `json:"max_abs_weight"`
+		MaxAbsGrad   jsonFloat `json:"max_abs_grad"`
+		Finite       bool      `json:"finite"`
+	}{
+		s.Step, s.Name,
+		jsonFloat(s.WeightNorm), jsonFloat(s.GradNorm), jsonFloat(s.UpdateRatio),
+		jsonFloat(s.MaxAbsWeight), jsonFloat(s.MaxAbsGrad),
+		finite,
+	})
+}
+
+// jsonFloat is a float64 that encodes NaN and ±Inf as strings.
+type jsonFloat float64
+
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	switch {
+	case math.IsNaN(v):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(v, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(v, -1):
+		return []byte(`"-Inf"`), nil
+	}
+
+	return json.Marshal(v)
+}
+
+func (f *jsonFloat) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `"NaN"`:
+		*f = jsonFloat(math.NaN())
+	case `"+Inf"`:
+		*f = jsonFloat(math.Inf(1))
+	case `"-Inf"`:
+		*f = jsonFloat(math.Inf(-1))
+	default:
+		return json.Unmarshal(data, (*float64)(f))
+	}
+
+	return nil
+}
+
+// UnmarshalJSON reads the encoding written by MarshalJSON.
+func (s *ParamStats) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Step         int       `json:"step"`
+		Name         string    `json:"param"`
+		WeightNorm   jsonFloat `json:"weight_norm"`
+		GradNorm     jsonFloat `json:"grad_norm"`
+		UpdateRatio  jsonFloat `json:"update_ratio"`
+		MaxAbsWeight jsonFloat `json:"max_abs_weight"`
+		MaxAbsGrad   jsonFloat `json:"max_abs_grad"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*s = ParamStats{
+		Step: raw.Step, Name: raw.Name,
+		WeightNorm: float64(raw.WeightNorm), GradNorm: float64(raw.GradNorm), UpdateRatio: float64(raw.UpdateRatio),
+		MaxAbsWeight: float64(raw.MaxAbsWeight), MaxAbsGrad: float64(raw.MaxAbsGrad),
+	}
+
+	return nil
+}
+
+// WriteJSONL writes one JSON object per recorded ParamStats.
+func (c *ParamStatsCollector) WriteJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, s := range c.records {
+		if err := enc.Encode(s); err != nil {
+			return errors.Join(fmt.Errorf("unable to write stats for %s at step %d", s.Name, s.Step), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamStatsCollector(t *testing.T) {
+	t.Run("computes norms, maxima and update ratio per param", func(t *testing.T) {
+		// Arrange
+		ll := &LinearLayer{In: 2, Out: 1, W: [][]float64{{3, -4}}, B: []float64{1}}
+		_, err := ll.Forward([]float64{1, 2})
+		assert.NoError(t, err)
+		_, err = ll.Backward([]float64{0.5})
+		assert.NoError(t, err)
+		c := NewParamStatsCollector()
+
+		// Act
+		c.BeforeStep(ll.Params())
+		ll.W[0][0] -= 0.3
+		ll.W[0][1] += 0.4
+		stats, err := c.AfterStep(1, ll.Params())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, stats, 2)
+		w := stats[0]
+		assert.Equal(t, "W", w.Name)
+		assert.Equal(t, 1, w.Step)
+		assert.InDelta(t, math.Sqrt(2.7*2.7+3.6*3.6), w.WeightNorm, 1e-12)
+		assert.InDelta(t, math.Sqrt(0.25+1), w.GradNorm, 1e-12)
+		assert.InDelta(t, 0.5/5, w.UpdateRatio, 1e-12)
+		assert.InDelta(t, 3.6, w.MaxAbsWeight, 1e-12)
+		assert.InDelta(t, 1.0, w.MaxAbsGrad, 1e-12)
+		assert.InDelta(t, 0.0, stats[1].UpdateRatio, 1e-12)
+	})
+
+	t.Run("update ratio is 0 without BeforeStep", func(t *testing.T) {
+		// Arrange
+		ll := &LinearLayer{In: 1, Out: 1, W: [][]float64{{2}}}
+		c := NewParamStatsCollector()
+
+		// Act
+		stats, err := c.AfterStep(0, ll.Params())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, stats[0].UpdateRatio)
+	})
+
+	t.Run("WriteJSONL writes one object per param per step", func(t *testing.T) {
+		// Arrange
+		ll := &LinearLayer{In: 1, Out: 1, W: [][]float64{{2}}, B: []float64{0}}
+		c := NewParamStatsCollector()
+		for step := range 3 {
+			c.BeforeStep(ll.Params())
+			ll.W[0][0] += 1
+			_, err := c.AfterStep(step, ll.Params())
+			assert.NoError(t, err)
+		}
+		var buf bytes.Buffer
+
+		// Act
+		err := c.WriteJSONL(&buf)
+
+		// Assert
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 6)
+		var last ParamStats
+		assert.NoError(t, json.Unmarshal([]byte(lines[4]), &last))
+		assert.Equal(t, 2, last.Step)
+		assert.Equal(t, "W", last.Name)
+		assert.InDelta(t, 0.25, last.UpdateRatio, 1e-12)
+		assert.Contains(t, lines[0], `"weight_norm"`)
+	})
+
+	t.Run("WriteJSONL encodes non-finite values", func(t *testing.T) {
+		// Arrange
+		ll := &LinearLayer{In: 1, Out: 2, W: [][]float64{{2}, {1}}, B: []float64{0, 0}}
+		params := ll.Params()
+		params[0].Grad[0][0] = math.NaN()
+		params[1].Grad[0][1] = math.Inf(1)
+		c := NewParamStatsCollector()
+		_, err := c.AfterStep(0, params)
+		assert.NoError(t, err)
+		var buf bytes.Buffer
+
+		// Act
+		err = c.WriteJSONL(&buf)
+
+		// Assert
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Contains(t, lines[0], `"grad_norm":"NaN"`)
+		assert.Contains(t, lines[0], `"finite":false`)
+		assert.Contains(t, lines[1], `"max_abs_grad":"+Inf"`)
+		var decoded ParamStats
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+		assert.True(t, math.IsNaN(decoded.GradNorm))
+		assert.InDelta(t, math.Sqrt(5), decoded.WeightNorm, 1e-12)
+	})
+}
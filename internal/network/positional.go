@@ -0,0 +1,128 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// PositionalEmbedding adds a per-position vector to each row of a sequence.
+// Learned tables are trained like any other parameter; sinusoidal tables are
+// fixed and expose no params.
+type PositionalEmbedding struct {
+	Context int
+	Dim     int
+	Table   [][]float64
+	Learned bool
+
+	GradTable [][]float64
+	length    int
+}
+
+// NewLearnedPositions creates a trainable table with entries drawn from
+// N(0, 0.02²), as in GPT-2.
+func NewLearnedPositions(context, dim int, rng *rand.Rand) (*PositionalEmbedding, error) {
+	if context <= 0 || dim <= 0 {
+		return nil, fmt.Errorf("invalid positional dims: Context=%d Dim=%d", context, dim)
+	}
+	if rng == nil {
+		return nil, fmt.Errorf("rng is required")
+	}
+
+	table := make([][]float64, context)
+	for pos := range table {
+		table[pos] = make([]float64, dim)
+		for j := range table[pos] {
+			table[pos][j] = 0.02 * rng.NormFloat64()
+		}
+	}
+
+	return &PositionalEmbedding{Context: context, Dim: dim, Table: table, Learned: true}, nil
+}
+
+// NewSinusoidalPositions creates the fixed encoding of "Attention Is All You
+// Need": sin on even dimensions and cos on odd ones, with wavelengths growing
+// geometrically from 2π to 10000·2π.
+func NewSinusoidalPositions(context, dim int) (*PositionalEmbedding, error) {
+	if context <= 0 || dim <= 0 {
+		return nil, fmt.Errorf("invalid positional dims: Context=%d Dim=%d", context, dim)
+	}
+
+	table := make([][]float64, context)
+	for pos := range table {
+		table[pos] = make([]float64, dim)
+		for j := range table[pos] {
+			angle := float64(pos) / math.Pow(10000, float64(j-j%2)/float64(dim))
+			if j%2 == 0 {
+				table[pos][j] = math.Sin(angle)
+			} else {
+				table[pos][j] = math.Cos(angle)
+			}
+		}
+	}
+
+	return &PositionalEmbedding{Context: context, Dim: dim, Table: table}, nil
+}
+
+// ForwardSequence returns X with the embedding of position t added to row t.
+func (p *PositionalEmbedding) ForwardSequence(X [][]float64) ([][]float64, error) {
+	if len(X) == 0 {
+		return nil, fmt.Errorf("sequence must have length")
+	}
+	if len(X) > p.Context {
+		return nil, fmt.Errorf("sequence of length %d exceeds context of %d", len(X), p.Context)
+	}
+
+	output := make([][]float64, len(X))
+	for t, x := range X {
+		if len(x) != p.Dim {
+			return nil, fmt.Errorf("dimension mismatch: row %d has length %d, expected %d", t, len(x), p.Dim)
+		}
+		output[t] = make([]float64, p.Dim)
+		for j, v := range x {
+			output[t][j] = v + p.Table[t][j]
+		}
+	}
+	p.length = len(X)
+
+	return output, nil
+}
+
+// BackwardSequence passes dY through unchanged, accumulating the table
+// gradient when the table is learned.
+func (p *PositionalEmbedding) BackwardSequence(dY [][]float64) ([][]float64, error) {
+	if len(dY) != p.length {
+		return nil, fmt.Errorf("dimension mismatch: dY has %d rows, last sequence had %d", len(dY), p.length)
+	}
+
+	if p.Learned {
+		p.ensureGrads()
+		for t, dy := range dY {
+			for j, g := range dy {
+				p.GradTable[t][j] += g
+			}
+		}
+	}
+
+	return dY, nil
+}
+
+func (p *PositionalEmbedding) Params() []Param {
+	if !p.Learned {
+		return nil
+	}
+	p.ensureGrads()
+
+	return []Param{{Name: "table", Value: p.Table, Grad: p.GradTable}}
+}
+
+func (p *PositionalEmbedding) ensureGrads() {
+	if len(p.GradTable) != len(p.Table) {
+		p.GradTable = make([][]float64, len(p.Table))
+	}
+	for pos, row := range p.Table {
+		if len(p.GradTable[pos]) != len(row) {
+			p.GradTable[pos] = make([]float64, len(row))
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package network
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPositionalEmbedding(t *testing.T) {
+	t.Run("sinusoidal positions follow sin/cos pairs", func(t *testing.T) {
+		// Act
+		p, err := NewSinusoidalPositions(3, 4)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{0, 1, 0, 1}, p.Table[0])
+		assert.InDeltaSlice(t, []float64{math.Sin(2), math.Cos(2), math.Sin(0.02), math.Cos(0.02)}, p.Table[2], 1e-12)
+		assert.Nil(t, p.Params())
+	})
+
+	t.Run("adds positions and accumulates learned gradients", func(t *testing.T) {
+		// Arrange
+		p, err := NewLearnedPositions(3, 2, rand.New(rand.NewPCG(1, 1)))
+		assert.NoError(t, err)
+		X := [][]float64{{1, 2}, {3, 4}}
+
+		// Act
+		Y, err := p.ForwardSequence(X)
+		assert.NoError(t, err)
+		dX, backErr := p.BackwardSequence([][]float64{{1, 1}, {2, 2}})
+
+		// Assert
+		assert.NoError(t, backErr)
+		assert.InDelta(t, 3+p.Table[1][0], Y[1][0], 1e-12)
+		assert.Equal(t, [][]float64{{1, 1}, {2, 2}}, dX)
+		assert.Equal(t, [][]float64{{1, 1}, {2, 2}, {0, 0}}, p.GradTable)
+	})
+
+	t.Run("rejects sequences longer than the context", func(t *testing.T) {
+		// Arrange
+		p, err := NewSinusoidalPositions(2, 2)
+		assert.NoError(t, err)
+
+		// Act
+		_, err = p.ForwardSequence([][]float64{{0, 0}, {0, 0}, {0, 0}})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,254 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// CellState is the state a recurrent cell carries between steps. C is the LSTM
+// cell state and is nil for cells that only have a hidden state.
+type CellState struct {
+	H []float64
+	C []float64
+}
+
+// Cell is a single step of a recurrent network. Step returns an opaque cache
+// that StepBackward needs to propagate gradients through that step.
+type Cell interface {
+	InputSize() int
+	HiddenSize() int
+	ZeroState() CellState
+	Step(x []float64, prev CellState) (CellState, any, error)
+	// StepBackward takes the gradient with respect to the step's output state
+	// and returns the gradients with respect to x and the previous state,
+	// accumulating parameter gradients along the way.
+	StepBackward(cache any, dNext CellState) ([]float64, CellState, error)
+	Params() []Param
+}
+
+// Gate is the affine pre-activation Input x + Hidden h shared by every
+// recurrent cell. Only Input carries a bias unless noted by the cell.
+type Gate struct {
+	Input  LinearLayer
+	Hidden LinearLayer
+}
+
+func newGate(in, hidden int, hiddenBias bool, rng *rand.Rand) (Gate, error) {
+	input, err := NewLinearLayer(in, hidden, true, XavierUniform, rng)
+	if err != nil {
+		return Gate{}, err
+	}
+	recurrent, err := NewLinearLayer(hidden, hidden, hiddenBias, XavierUniform, rng)
+	if err != nil {
+		return Gate{}, err
+	}
+
+	return Gate{Input: *input, Hidden: *recurrent}, nil
+}
+
+// forward returns Input x and Hidden h separately; most cells just add them.
+func (g *Gate) forward(x, h []float64) ([]float64, []float64, error) {
+	ix, err := g.Input.Forward(x)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("gate unable to forward input"), err)
+	}
+	hh, err := g.Hidden.Forward(h)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("gate unable to forward hidden"), err)
+	}
+
+	return ix, hh, nil
+}
+
+func (g *Gate) preActivation(x, h []float64) ([]float64, error) {
+	ix, hh, err := g.forward(x, h)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range ix {
+		ix[i] += hh[i]
+	}
+
+	return ix, nil
+}
+
+// backward accumulates gradients for the input and hidden sides given their
+// own upstream gradients and adds their input gradients into dx and dh.
+func (g *Gate) backward(x, h, dInput, dHidden, dx, dh []float64) error {
+	gx, err := g.Input.backward(x, dInput)
+	if err != nil {
+		return errors.Join(errors.New("gate unable to backward input"), err)
+	}
+	gh, err := g.Hidden.backward(h, dHidden)
+	if err != nil {
+		return errors.Join(errors.New("gate unable to backward hidden"), err)
+	}
+
+	addInto(dx, gx)
+	addInto(dh, gh)
+
+	return nil
+}
+
+func (g *Gate) params(name string) []Param {
+	return append(
+		prefixParams(name+".input", g.Input.Params()),
+		prefixParams(name+".hidden", g.Hidden.Params())...,
+	)
+}
+
+// Recurrent runs a Cell over token sequences: Embedding, then the cell at each
+// step, then Out to produce per-step logits for BatchCrossEntropy.
+type Recurrent struct {
+	Embedding *Embedding
+	Cell      Cell
+	Out       *LinearLayer
+	// TruncateBPTT splits the sequence into chunks of this many steps and stops
+	// gradients flowing from one chunk into the previous. 0 backpropagates
+	// through the whole sequence.
+	TruncateBPTT int
+
+	// state is carried from the end of one ForwardSequence into the start of
+	// the next, without gradients flowing between them.
+	state  CellState
+	caches []any
+	hs     [][]float64
+}
+
+func NewRecurrent(embedding *Embedding, cell Cell, out *LinearLayer) (*Recurrent, error) {
+	r := &Recurrent{Embedding: embedding, Cell: cell, Out: out}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Recurrent) Validate() error {
+	if r.Embedding == nil || r.Cell == nil || r.Out == nil {
+		return fmt.Errorf("embedding, cell and out are required")
+	}
+	if err := r.Embedding.Validate(); err != nil {
+		return errors.Join(errors.New("recurrent failed to validate embedding"), err)
+	}
+	if err := r.Out.Validate(); err != nil {
+		return errors.Join(errors.New("recurrent failed to validate output layer"), err)
+	}
+	if r.Embedding.Dim != r.Cell.InputSize() {
+		return fmt.Errorf("dimension mismatch: embedding dim %d and cell input size %d", r.Embedding.Dim, r.Cell.InputSize())
+	}
+	if r.Cell.HiddenSize() != r.Out.In {
+		return fmt.Errorf("dimension mismatch: cell hidden size %d and output layer input size %d", r.Cell.HiddenSize(), r.Out.In)
+	}
+	if r.TruncateBPTT < 0 {
+		return fmt.Errorf("TruncateBPTT must be non-negative, got %d", r.TruncateBPTT)
+	}
+
+	return nil
+}
+
+// ForwardSequence returns the logits at every step of tokens, starting from the
+// state left by the previous call.
+func (r *Recurrent) ForwardSequence(tokens []int) ([][]float64, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	X, err := r.Embedding.ForwardSequence(tokens)
+	if err != nil {
+		return nil, errors.Join(errors.New("recurrent unable to embed tokens"), err)
+	}
+
+	state := r.State()
+	caches := make([]any, len(X))
+	hs := make([][]float64, len(X))
+	logits := make([][]float64, len(X))
+
+	for t, x := range X {
+		var cache any
+		state, cache, err = r.Cell.Step(x, state)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("recurrent cell failed at step %d", t), err)
+		}
+		caches[t] = cache
+		hs[t] = state.H
+
+		logits[t], err = r.Out.Forward(state.H)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("recurrent output layer failed at step %d", t), err)
+		}
+	}
+
+	r.state = state
+	r.caches = caches
+	r.hs = hs
+
+	return logits, nil
+}
+
+// BackwardSequence backpropagates the gradient of the per-step logits through
+// time, honouring TruncateBPTT.
+func (r *Recurrent) BackwardSequence(dLogits [][]float64) error {
+	if len(dLogits) != len(r.caches) {
+		return fmt.Errorf("dimension mismatch: dLogits has %d steps, last sequence had %d", len(dLogits), len(r.caches))
+	}
+
+	dX := make([][]float64, len(dLogits))
+	dNext := CellState{}
+
+	for t := len(dLogits) - 1; t >= 0; t-- {
+		dh, err := r.Out.backward(r.hs[t], dLogits[t])
+		if err != nil {
+			return errors.Join(fmt.Errorf("recurrent output layer backward failed at step %d", t), err)
+		}
+		for i, g := range dNext.H {
+			dh[i] += g
+		}
+
+		dx, dPrev, err := r.Cell.StepBackward(r.caches[t], CellState{H: dh, C: dNext.C})
+		if err != nil {
+			return errors.Join(fmt.Errorf("recurrent cell backward failed at step %d", t), err)
+		}
+		dX[t] = dx
+
+		dNext = dPrev
+		if r.TruncateBPTT > 0 && t%r.TruncateBPTT == 0 {
+			dNext = CellState{}
+		}
+	}
+
+	if err := r.Embedding.BackwardSequence(dX); err != nil {
+		return errors.Join(errors.New("recurrent unable to backward embedding"), err)
+	}
+
+	return nil
+}
+
+// State returns the state the next ForwardSequence starts from.
+func (r *Recurrent) State() CellState {
+	if r.state.H == nil {
+		return r.Cell.ZeroState()
+	}
+
+	return r.state
+}
+
+// ResetState makes the next ForwardSequence start from the zero state, e.g.
+// at the start of an epoch or a new document.
+func (r *Recurrent) ResetState() {
+	r.state = CellState{}
+}
+
+func (r *Recurrent) Params() []Param {
+	params := prefixParams("embedding", r.Embedding.Params())
+	params = append(params, prefixParams("cell", r.Cell.Params())...)
+	return append(params, prefixParams("out", r.Out.Params())...)
+}
+
+func addInto(dst, src []float64) {
+	for i := range src {
+		dst[i] += src[i]
+	}
+}
@@ -0,0 +1,173 @@
+package network
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testTokens  = []int{0, 2, 1, 3, 2}
+	testTargets = []int{2, 1, 3, 2, 0}
+)
+
+func newTestRecurrent(t *testing.T, newCell func(in, hidden int, rng *rand.Rand) (Cell, error)) *Recurrent {
+	t.Helper()
+	rng := rand.New(rand.NewPCG(3, 3))
+
+	embedding, err := NewEmbedding(4, 3, rng)
+	assert.NoError(t, err)
+	cell, err := newCell(3, 5, rng)
+	assert.NoError(t, err)
+	out, err := NewLinearLayer(5, 4, true, XavierUniform, rng)
+	assert.NoError(t, err)
+	r, err := NewRecurrent(embedding, cell, out)
+	assert.NoError(t, err)
+
+	return r
+}
+
+// gradCheckSequence checks the gradients of the mean cross-entropy over
+// testTokens, starting every evaluation from the zero state.
+func gradCheckSequence(r *Recurrent) (GradCheckReport, error) {
+	loss := func() (float64, error) {
+		r.ResetState()
+		logits, err := r.ForwardSequence(testTokens)
+		if err != nil {
+			return 0, err
+		}
+		res, err := BatchCrossEntropy(logits, testTargets, CrossEntropyOptions{})
+		return res.Loss, err
+	}
+
+	backward := func() error {
+		r.ResetState()
+		logits, err := r.ForwardSequence(testTokens)
+		if err != nil {
+			return err
+		}
+		res, err := BatchCrossEntropy(logits, testTargets, CrossEntropyOptions{})
+		if err != nil {
+			return err
+		}
+		return r.BackwardSequence(res.Grad)
+	}
+
+	return GradCheckLoss(r.Params(), loss, backward, GradCheckConfig{})
+}
+
+func TestRecurrent(t *testing.T) {
+	newRNN := func(in, hidden int, rng *rand.Rand) (Cell, error) { return NewRNNCell(in, hidden, rng) }
+
+	t.Run("returns logits per step compatible with cross-entropy", func(t *testing.T) {
+		// Arrange
+		r := newTestRecurrent(t, newRNN)
+
+		// Act
+		logits, err := r.ForwardSequence(testTokens)
+		assert.NoError(t, err)
+		res, lossErr := BatchCrossEntropy(logits, testTargets, CrossEntropyOptions{})
+
+		// Assert
+		assert.NoError(t, lossErr)
+		assert.Len(t, logits, len(testTokens))
+		for _, row := range logits {
+			assert.Len(t, row, 4)
+		}
+		assert.NoError(t, r.BackwardSequence(res.Grad))
+	})
+
+	t.Run("carries hidden state between calls", func(t *testing.T) {
+		// Arrange
+		r := newTestRecurrent(t, newRNN)
+		whole, err := r.ForwardSequence(testTokens)
+		assert.NoError(t, err)
+		r.ResetState()
+
+		// Act
+		first, err := r.ForwardSequence(testTokens[:2])
+		assert.NoError(t, err)
+		second, err := r.ForwardSequence(testTokens[2:])
+		assert.NoError(t, err)
+
+		// Assert
+		split := append(first, second...)
+		for step := range whole {
+			assert.InDeltaSlice(t, whole[step], split[step], 1e-12)
+		}
+	})
+
+	t.Run("ResetState restarts from the zero state", func(t *testing.T) {
+		// Arrange
+		r := newTestRecurrent(t, newRNN)
+		first, err := r.ForwardSequence(testTokens)
+		assert.NoError(t, err)
+
+		// Act
+		r.ResetState()
+		again, err := r.ForwardSequence(testTokens)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, first, again)
+	})
+
+	t.Run("truncation stops gradients crossing chunk boundaries", func(t *testing.T) {
+		// Arrange
+		r := newTestRecurrent(t, newRNN)
+		r.TruncateBPTT = 2
+		tokens := []int{0, 1, 2, 3}
+		_, err := r.ForwardSequence(tokens)
+		assert.NoError(t, err)
+		dLogits := [][]float64{make([]float64, 4), make([]float64, 4), make([]float64, 4), {1, -1, 0.5, 0}}
+
+		// Act
+		err = r.BackwardSequence(dLogits)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, make([]float64, 3), r.Embedding.GradTable[0])
+		assert.Equal(t, make([]float64, 3), r.Embedding.GradTable[1])
+		assert.NotEqual(t, make([]float64, 3), r.Embedding.GradTable[2])
+	})
+
+	t.Run("full backprop through time matches finite differences", func(t *testing.T) {
+		// Arrange
+		r := newTestRecurrent(t, newRNN)
+
+		// Act
+		report, err := gradCheckSequence(r)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-5))
+	})
+
+	t.Run("rejects mismatched dimensions", func(t *testing.T) {
+		// Arrange
+		rng := rand.New(rand.NewPCG(1, 1))
+		embedding, _ := NewEmbedding(4, 3, rng)
+		cell, _ := NewRNNCell(2, 5, rng)
+		out, _ := NewLinearLayer(5, 4, true, XavierUniform, rng)
+
+		// Act
+		_, err := NewRecurrent(embedding, cell, out)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects backward with the wrong number of steps", func(t *testing.T) {
+		// Arrange
+		r := newTestRecurrent(t, newRNN)
+		_, err := r.ForwardSequence(testTokens)
+		assert.NoError(t, err)
+
+		// Act
+		err = r.BackwardSequence(make([][]float64, 2))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,99 @@
+package network
+
+import (
+	"fmt"
+	"math"
+)
+
+// MSE is the mean squared error between targets y and predictions yHat. The
+// returned gradient is with respect to yHat.
+func MSE(y, yHat []float64) (float64, []float64, error) {
+	if err := validateRegression(y, yHat); err != nil {
+		return 0, nil, err
+	}
+
+	n := float64(len(y))
+	loss := 0.0
+	grad := make([]float64, len(y))
+	for i := range y {
+		d := yHat[i] - y[i]
+		loss += d * d
+		grad[i] = 2 * d / n
+	}
+
+	return loss / n, grad, nil
+}
+
+// MAE is the mean absolute error between targets y and predictions yHat. The
+// subgradient at yHat == y is taken as 0.
+func MAE(y, yHat []float64) (float64, []float64, error) {
+	if err := validateRegression(y, yHat); err != nil {
+		return 0, nil, err
+	}
+
+	n := float64(len(y))
+	loss := 0.0
+	grad := make([]float64, len(y))
+	for i := range y {
+		d := yHat[i] - y[i]
+		loss += math.Abs(d)
+		switch {
+		case d > 0:
+			grad[i] = 1 / n
+		case d < 0:
+			grad[i] = -1 / n
+		}
+	}
+
+	return loss / n, grad, nil
+}
+
+// Huber is quadratic for residuals within delta and linear beyond it, making it
+// less sensitive to outliers than MSE while staying smooth around 0.
+func Huber(y, yHat []float64, delta float64) (float64, []float64, error) {
+	if delta <= 0 || math.IsNaN(delta) || math.IsInf(delta, 0) {
+		return 0, nil, fmt.Errorf("delta must be positive and finite, got %v", delta)
+	}
+	if err := validateRegression(y, yHat); err != nil {
+		return 0, nil, err
+	}
+
+	n := float64(len(y))
+	loss := 0.0
+	grad := make([]float64, len(y))
+	for i := range y {
+		d := yHat[i] - y[i]
+		if math.Abs(d) <= delta {
+			loss += 0.5 * d * d
+			grad[i] = d / n
+			continue
+		}
+		loss += delta * (math.Abs(d) - 0.5*delta)
+		grad[i] = math.Copysign(delta, d) / n
+	}
+
+	return loss / n, grad, nil
+}
+
+func validateRegression(y, yHat []float64) error {
+	if len(y) == 0 {
+		return fmt.Errorf("y must have length")
+	}
+	if len(yHat) == 0 {
+		return fmt.Errorf("yHat must have length")
+	}
+	if len(y) != len(yHat) {
+		return fmt.Errorf("y and yHat must have same length")
+	}
+
+	for i := range y {
+		if math.IsNaN(y[i]) || math.IsInf(y[i], 0) {
+			return fmt.Errorf("y[%d] is NaN/Inf", i)
+		}
+		if math.IsNaN(yHat[i]) || math.IsInf(yHat[i], 0) {
+			return fmt.Errorf("yHat[%d] is NaN/Inf", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type regressionLoss func(y, yHat []float64) (float64, []float64, error)
+
+func TestRegressionLosses(t *testing.T) {
+	y := []float64{1.0, -2.0, 0.5, 3.0}
+	yHat := []float64{1.5, -2.0, 2.5, 0.0}
+
+	huber := func(y, yHat []float64) (float64, []float64, error) {
+		return Huber(y, yHat, 1.0)
+	}
+
+	t.Run("MSE returns the mean squared residual", func(t *testing.T) {
+		// Act
+		loss, _, err := MSE(y, yHat)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, (0.25+0+4+9)/4, loss, 1e-12)
+	})
+
+	t.Run("MAE returns the mean absolute residual", func(t *testing.T) {
+		// Act
+		loss, grad, err := MAE(y, yHat)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, (0.5+0+2+3)/4, loss, 1e-12)
+		assert.InDeltaSlice(t, []float64{0.25, 0, 0.25, -0.25}, grad, 1e-12)
+	})
+
+	t.Run("Huber is quadratic inside delta and linear outside", func(t *testing.T) {
+		// Act
+		loss, _, err := Huber(y, yHat, 1.0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, (0.125+0+1.5+2.5)/4, loss, 1e-12)
+	})
+
+	t.Run("Huber with a large delta is half of MSE", func(t *testing.T) {
+		// Act
+		h, _, err1 := Huber(y, yHat, 100)
+		m, _, err2 := MSE(y, yHat)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.InDelta(t, m/2, h, 1e-12)
+	})
+
+	t.Run("gradients match central differences", func(t *testing.T) {
+		// Arrange
+		// Residuals are kept away from 0 and from the Huber knee.
+		pred := []float64{1.5, -2.3, 2.5, 0.0}
+		losses := map[string]regressionLoss{"MSE": MSE, "MAE": MAE, "Huber": huber}
+		const h = 1e-6
+
+		for name, fn := range losses {
+			_, grad, err := fn(y, pred)
+			assert.NoError(t, err)
+
+			for i := range pred {
+				// Act
+				plus := append([]float64(nil), pred...)
+				minus := append([]float64(nil), pred...)
+				plus[i] += h
+				minus[i] -= h
+				lp, _, err1 := fn(y, plus)
+				lm, _, err2 := fn(y, minus)
+
+				// Assert
+				assert.NoError(t, err1)
+				assert.NoError(t, err2)
+				assert.InDelta(t, (lp-lm)/(2*h), grad[i], 1e-6, name)
+			}
+		}
+	})
+
+	t.Run("returns errors for invalid input", func(t *testing.T) {
+		// Arrange
+		losses := map[string]regressionLoss{"MSE": MSE, "MAE": MAE, "Huber": huber}
+
+		for name, fn := range losses {
+			// Act
+			_, _, errEmpty := fn([]float64{}, []float64{1})
+			_, _, errLen := fn([]float64{1, 2}, []float64{1})
+			_, _, errNaN := fn([]float64{1}, []float64{math.NaN()})
+			_, _, errInf := fn([]float64{math.Inf(1)}, []float64{1})
+
+			// Assert
+			assert.Error(t, errEmpty, name)
+			assert.Error(t, errLen, name)
+			assert.Error(t, errNaN, name)
+			assert.Error(t, errInf, name)
+		}
+	})
+
+	t.Run("Huber returns an error for a non-positive delta", func(t *testing.T) {
+		// Act
+		_, _, err := Huber(y, yHat, 0)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,61 @@
+package network
+
+import (
+	"fmt"
+	"math"
+)
+
+// ParamFilter selects the params a penalty or weight decay applies to. A nil
+// filter selects every param.
+type ParamFilter func(p Param) bool
+
+// WeightsOnly selects weights, excluding biases and normalisation gains.
+func WeightsOnly(p Param) bool {
+	return p.Kind == ParamWeight
+}
+
+// Penalty is an L1 and/or L2 regulariser over a group of params. Use several
+// Penalties with different filters for per-group strengths.
+type Penalty struct {
+	// L1 adds L1 * sum|w| to the loss.
+	L1 float64
+	// L2 adds 0.5 * L2 * sum w^2 to the loss, so its gradient is L2 * w.
+	L2     float64
+	Filter ParamFilter
+}
+
+// Apply returns the penalty to add to the loss and accumulates its gradient
+// into the Grad buffers of the selected params, so call it after Backward and
+// before the optimizer step. The L1 subgradient at 0 is taken as 0.
+func (r Penalty) Apply(params []Param) (float64, error) {
+	if r.L1 < 0 || r.L2 < 0 || math.IsNaN(r.L1) || math.IsNaN(r.L2) {
+		return 0, fmt.Errorf("penalty strengths must be non-negative, got L1=%v L2=%v", r.L1, r.L2)
+	}
+
+	penalty := 0.0
+	for _, p := range params {
+		if r.Filter != nil && !r.Filter(p) {
+			continue
+		}
+		if len(p.Grad) != len(p.Value) {
+			return 0, fmt.Errorf("param %s has %d value rows and %d grad rows", p.Name, len(p.Value), len(p.Grad))
+		}
+
+		for i, row := range p.Value {
+			for j, w := range row {
+				penalty += r.L1*math.Abs(w) + 0.5*r.L2*w*w
+
+				g := r.L2 * w
+				switch {
+				case w > 0:
+					g += r.L1
+				case w < 0:
+					g -= r.L1
+				}
+				p.Grad[i][j] += g
+			}
+		}
+	}
+
+	return penalty, nil
+}
@@ -0,0 +1,77 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPenalty(t *testing.T) {
+	newLayer := func() *LinearLayer {
+		return &LinearLayer{In: 2, Out: 1, W: [][]float64{{2, -3}}, B: []float64{4}}
+	}
+
+	t.Run("L2 adds half the squared norm and its gradient", func(t *testing.T) {
+		// Arrange
+		ll := newLayer()
+
+		// Act
+		penalty, err := Penalty{L2: 0.1}.Apply(ll.Params())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.05*(4+9+16), penalty, 1e-12)
+		assert.InDeltaSlice(t, []float64{0.2, -0.3}, ll.GradW[0], 1e-12)
+		assert.InDeltaSlice(t, []float64{0.4}, ll.GradB, 1e-12)
+	})
+
+	t.Run("L1 adds the absolute norm and its sign as gradient", func(t *testing.T) {
+		// Arrange
+		ll := newLayer()
+
+		// Act
+		penalty, err := Penalty{L1: 0.5}.Apply(ll.Params())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.5*9, penalty, 1e-12)
+		assert.InDeltaSlice(t, []float64{0.5, -0.5}, ll.GradW[0], 1e-12)
+	})
+
+	t.Run("WeightsOnly excludes biases", func(t *testing.T) {
+		// Arrange
+		ll := newLayer()
+
+		// Act
+		penalty, err := Penalty{L2: 1, Filter: WeightsOnly}.Apply(ll.Params())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.5*13, penalty, 1e-12)
+		assert.InDeltaSlice(t, []float64{0}, ll.GradB, 1e-12)
+	})
+
+	t.Run("gradient accumulates onto the loss gradient", func(t *testing.T) {
+		// Arrange
+		ll := newLayer()
+		_, err := ll.Forward([]float64{1, 1})
+		assert.NoError(t, err)
+		_, err = ll.Backward([]float64{1})
+		assert.NoError(t, err)
+
+		// Act
+		_, err = Penalty{L2: 1}.Apply(ll.Params())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{3, -2}, ll.GradW[0], 1e-12)
+	})
+
+	t.Run("returns an error for negative strengths", func(t *testing.T) {
+		// Act
+		_, err := Penalty{L1: -1}.Apply(newLayer().Params())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,260 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// Residual adds a skip connection around Body: y = Body(x) + skip(x), where
+// skip is the identity or, when the body changes the width, a Projection.
+// Norm is an optional post-normalisation applied after the addition.
+type Residual struct {
+	Body       Layer
+	Projection *LinearLayer
+	Norm       Layer
+}
+
+// NewResidual wraps body, which maps vectors of size in to size out. When the
+// sizes differ a bias-free projection of the skip path is initialised with
+// XavierUniform from a seeded RNG. in and out are checked against the body
+// when its widths are known, e.g. for a *Block.
+func NewResidual(body Layer, in, out int, seed uint64) (*Residual, error) {
+	if body == nil {
+		return nil, fmt.Errorf("residual body is nil and is required")
+	}
+	if in <= 0 || out <= 0 {
+		return nil, fmt.Errorf("invalid residual dims: In=%d Out=%d", in, out)
+	}
+	if bodyIn, bodyOut, ok := layerWidths(body); ok && (bodyIn != in || bodyOut != out) {
+		return nil, fmt.Errorf("dimension mismatch: body maps %d to %d, residual given In=%d Out=%d", bodyIn, bodyOut, in, out)
+	}
+
+	r := &Residual{Body: body}
+	if in != out {
+		proj, err := NewLinearLayer(in, out, false, XavierUniform, rand.New(rand.NewPCG(seed, seed)))
+		if err != nil {
+			return nil, errors.Join(errors.New("unable to create residual projection"), err)
+		}
+		r.Projection = proj
+	}
+
+	return r, nil
+}
+
+func (r *Residual) Forward(x []float64) ([]float64, error) {
+	if r.Body == nil {
+		return nil, fmt.Errorf("residual body is nil and is required")
+	}
+
+	y, err := r.Body.Forward(x)
+	if err != nil {
+		return nil, errors.Join(errors.New("residual unable to forward body"), err)
+	}
+
+	skip := x
+	if r.Projection != nil {
+		skip, err = r.Projection.Forward(x)
+		if err != nil {
+			return nil, errors.Join(errors.New("residual unable to forward projection"), err)
+		}
+	}
+
+	out, err := addResidual(y, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Norm != nil {
+		out, err = r.Norm.Forward(out)
+		if err != nil {
+			return nil, errors.Join(errors.New("residual unable to forward norm"), err)
+		}
+	}
+
+	return out, nil
+}
+
+// Backward sends dy down both paths and sums their input gradients.
+func (r *Residual) Backward(dy []float64) ([]float64, error) {
+	var err error
+	if r.Norm != nil {
+		dy, err = r.Norm.Backward(dy)
+		if err != nil {
+			return nil, errors.Join(errors.New("residual unable to backward norm"), err)
+		}
+	}
+
+	dx, err := r.Body.Backward(dy)
+	if err != nil {
+		return nil, errors.Join(errors.New("residual unable to backward body"), err)
+	}
+
+	dSkip := dy
+	if r.Projection != nil {
+		dSkip, err = r.Projection.Backward(dy)
+		if err != nil {
+			return nil, errors.Join(errors.New("residual unable to backward projection"), err)
+		}
+	}
+
+	return addResidual(dx, dSkip)
+}
+
+func (r *Residual) ForwardBatch(X [][]float64) ([][]float64, error) {
+	body, err := asBatchLayer(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	Y, err := body.ForwardBatch(X)
+	if err != nil {
+		return nil, errors.Join(errors.New("residual unable to forward body batch"), err)
+	}
+
+	skip := X
+	if r.Projection != nil {
+		skip, err = r.Projection.ForwardBatch(X)
+		if err != nil {
+			return nil, errors.Join(errors.New("residual unable to forward projection batch"), err)
+		}
+	}
+
+	out, err := addResidualBatch(Y, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Norm != nil {
+		norm, err := asBatchLayer(r.Norm)
+		if err != nil {
+			return nil, err
+		}
+		out, err = norm.ForwardBatch(out)
+		if err != nil {
+			return nil, errors.Join(errors.New("residual unable to forward norm batch"), err)
+		}
+	}
+
+	return out, nil
+}
+
+func (r *Residual) BackwardBatch(dY [][]float64) ([][]float64, error) {
+	if r.Norm != nil {
+		norm, err := asBatchLayer(r.Norm)
+		if err != nil {
+			return nil, err
+		}
+		dY, err = norm.BackwardBatch(dY)
+		if err != nil {
+			return nil, errors.Join(errors.New("residual unable to backward norm batch"), err)
+		}
+	}
+
+	body, err := asBatchLayer(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	dX, err := body.BackwardBatch(dY)
+	if err != nil {
+		return nil, errors.Join(errors.New("residual unable to backward body batch"), err)
+	}
+
+	dSkip := dY
+	if r.Projection != nil {
+		dSkip, err = r.Projection.BackwardBatch(dY)
+		if err != nil {
+			return nil, errors.Join(errors.New("residual unable to backward projection batch"), err)
+		}
+	}
+
+	return addResidualBatch(dX, dSkip)
+}
+
+func (r *Residual) Params() []Param {
+	params := prefixParams("body", r.Body.Params())
+	if r.Projection != nil {
+		params = append(params, prefixParams("projection", r.Projection.Params())...)
+	}
+	if r.Norm != nil {
+		params = append(params, prefixParams("norm", r.Norm.Params())...)
+	}
+
+	return params
+}
+
+// SetTraining switches the body and norm if they have a training mode.
+func (r *Residual) SetTraining(training bool) {
+	for _, l := range []Layer{r.Body, r.Norm} {
+		if m, ok := l.(TrainingMode); ok {
+			m.SetTraining(training)
+		}
+	}
+}
+
+// Training reports whether the body or norm is in training mode.
+func (r *Residual) Training() bool {
+	return anyTraining(r.Body, r.Norm)
+}
+
+func addResidual(y, skip []float64) ([]float64, error) {
+	if len(y) != len(skip) {
+		return nil, fmt.Errorf("dimension mismatch: body output has length %d, skip has length %d; set a Projection", len(y), len(skip))
+	}
+
+	out := make([]float64, len(y))
+	for i := range y {
+		out[i] = y[i] + skip[i]
+	}
+
+	return out, nil
+}
+
+func addResidualBatch(Y, skip [][]float64) ([][]float64, error) {
+	if len(Y) != len(skip) {
+		return nil, fmt.Errorf("dimension mismatch: body output has %d rows, skip has %d", len(Y), len(skip))
+	}
+
+	out := make([][]float64, len(Y))
+	for idx := range Y {
+		row, err := addResidual(Y[idx], skip[idx])
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("residual failed on row %d", idx), err)
+		}
+		out[idx] = row
+	}
+
+	return out, nil
+}
+
+// layerWidths returns the input and output sizes of the layers whose widths
+// are known up front.
+func layerWidths(l Layer) (int, int, bool) {
+	switch l := l.(type) {
+	case *LinearLayer:
+		return l.In, l.Out, true
+	case *Block:
+		return l.LinearLayer.In, l.LinearLayer.Out, true
+	case *MLP:
+		return l.Hidden.LinearLayer.In, l.Out.Out, true
+	case *LayerNorm:
+		return l.Size, l.Size, true
+	case *BatchNorm1d:
+		return l.Size, l.Size, true
+	case *Residual:
+		if l.Projection != nil {
+			return l.Projection.In, l.Projection.Out, true
+		}
+		return layerWidths(l.Body)
+	case *Sequential:
+		if len(l.Layers) == 0 {
+			return 0, 0, false
+		}
+		in, _, okIn := layerWidths(l.Layers[0])
+		_, out, okOut := layerWidths(l.Layers[len(l.Layers)-1])
+		return in, out, okIn && okOut
+	}
+
+	return 0, 0, false
+}
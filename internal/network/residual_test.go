@@ -0,0 +1,143 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResidual(t *testing.T) {
+	newBody := func(in, out int) *Block {
+		W := make([][]float64, out)
+		for o := range W {
+			W[o] = make([]float64, in)
+			for i := range W[o] {
+				W[o][i] = 0.3*float64(o+1) - 0.2*float64(i+1)
+			}
+		}
+		return &Block{
+			LinearLayer:  LinearLayer{In: in, Out: out, W: W, B: make([]float64, out)},
+			Nonlinearity: ReLU{},
+		}
+	}
+
+	t.Run("adds the input to the body output", func(t *testing.T) {
+		// Arrange
+		body := newBody(2, 2)
+		r, err := NewResidual(body, 2, 2, 0)
+		assert.NoError(t, err)
+		x := []float64{1, 2}
+		bodyOut, err := newBody(2, 2).Forward(x)
+		assert.NoError(t, err)
+
+		// Act
+		y, err := r.Forward(x)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, r.Projection)
+		assert.InDeltaSlice(t, []float64{bodyOut[0] + 1, bodyOut[1] + 2}, y, 1e-12)
+	})
+
+	t.Run("projects the skip path when In != Out", func(t *testing.T) {
+		// Arrange
+		r, err := NewResidual(newBody(2, 3), 2, 3, 9)
+		assert.NoError(t, err)
+
+		// Act
+		y, err := r.Forward([]float64{1.3, -0.4})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, y, 3)
+		assert.NotNil(t, r.Projection)
+		assert.Equal(t, 2, r.Projection.In)
+		assert.Equal(t, 3, r.Projection.Out)
+	})
+
+	t.Run("NewResidual rejects widths that disagree with the body", func(t *testing.T) {
+		// Act
+		_, errIn := NewResidual(newBody(2, 3), 4, 3, 0)
+		_, errOut := NewResidual(newBody(2, 3), 2, 2, 0)
+
+		// Assert
+		assert.ErrorContains(t, errIn, "dimension mismatch")
+		assert.ErrorContains(t, errOut, "dimension mismatch")
+	})
+
+	t.Run("rejects mismatched widths without a projection", func(t *testing.T) {
+		// Arrange
+		r := &Residual{Body: newBody(2, 3)}
+
+		// Act
+		_, err := r.Forward([]float64{1, 2})
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("gradients match finite differences with projection and post-norm", func(t *testing.T) {
+		// Arrange
+		r, err := NewResidual(newBody(2, 3), 2, 3, 4)
+		assert.NoError(t, err)
+		r.Norm, err = NewLayerNorm(3)
+		assert.NoError(t, err)
+
+		// Act
+		report, err := GradCheck(r, []float64{1.3, -0.4}, GradCheckConfig{Seed: 8})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-6))
+	})
+
+	t.Run("builds a ResNet-style MLP from the same primitives", func(t *testing.T) {
+		// Arrange
+		ln, err := NewLayerNorm(3)
+		assert.NoError(t, err)
+		pre := newBody(3, 3)
+		pre.PreNorm = ln
+		first, err := NewResidual(newBody(2, 3), 2, 3, 1)
+		assert.NoError(t, err)
+		second, err := NewResidual(pre, 3, 3, 2)
+		assert.NoError(t, err)
+		model, err := NewSequential(first, second, &LinearLayer{In: 3, Out: 2, W: [][]float64{{0.5, -0.2, 0.1}, {0.3, 0.4, -0.6}}})
+		assert.NoError(t, err)
+
+		// Act
+		report, err := GradCheck(model, []float64{1.3, -0.4}, GradCheckConfig{Seed: 3})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-6))
+	})
+
+	t.Run("batch passes match single-sample passes", func(t *testing.T) {
+		// Arrange
+		r, err := NewResidual(newBody(2, 3), 2, 3, 4)
+		assert.NoError(t, err)
+		X := [][]float64{{1.3, -0.4}, {0.2, 0.9}}
+
+		// Act
+		report, err := gradCheckBatch(r, X)
+		Y, err2 := r.ForwardBatch(X)
+		y, err3 := r.Forward(X[1])
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, err2)
+		assert.NoError(t, err3)
+		assert.NoError(t, report.Check(1e-6))
+		assert.InDeltaSlice(t, y, Y[1], 1e-12)
+	})
+
+	t.Run("NewResidual rejects a nil body and invalid dims", func(t *testing.T) {
+		// Act
+		_, errNil := NewResidual(nil, 2, 2, 0)
+		_, errDims := NewResidual(newBody(2, 2), 0, 2, 0)
+
+		// Assert
+		assert.Error(t, errNil)
+		assert.Error(t, errDims)
+	})
+}
@@ -0,0 +1,76 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// RNNCell is the Elman cell h' = tanh(Input x + Hidden h + b).
+type RNNCell struct {
+	Gate Gate
+}
+
+type rnnCache struct {
+	x, h, hNext []float64
+}
+
+func NewRNNCell(in, hidden int, rng *rand.Rand) (*RNNCell, error) {
+	gate, err := newGate(in, hidden, false, rng)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create rnn cell"), err)
+	}
+
+	return &RNNCell{Gate: gate}, nil
+}
+
+func (c *RNNCell) InputSize() int  { return c.Gate.Input.In }
+func (c *RNNCell) HiddenSize() int { return c.Gate.Hidden.Out }
+
+func (c *RNNCell) ZeroState() CellState {
+	return CellState{H: make([]float64, c.HiddenSize())}
+}
+
+func (c *RNNCell) Step(x []float64, prev CellState) (CellState, any, error) {
+	pre, err := c.Gate.preActivation(x, prev.H)
+	if err != nil {
+		return CellState{}, nil, err
+	}
+
+	h := make([]float64, len(pre))
+	for i, v := range pre {
+		h[i] = math.Tanh(v)
+	}
+
+	cache := &rnnCache{x: append([]float64(nil), x...), h: append([]float64(nil), prev.H...), hNext: h}
+
+	return CellState{H: h}, cache, nil
+}
+
+func (c *RNNCell) StepBackward(cache any, dNext CellState) ([]float64, CellState, error) {
+	s, ok := cache.(*rnnCache)
+	if !ok {
+		return nil, CellState{}, fmt.Errorf("rnn cell given cache of type %T", cache)
+	}
+	if len(dNext.H) != len(s.hNext) {
+		return nil, CellState{}, fmt.Errorf("dimension mismatch: dh has length %d, expected %d", len(dNext.H), len(s.hNext))
+	}
+
+	dPre := make([]float64, len(s.hNext))
+	for i, h := range s.hNext {
+		dPre[i] = dNext.H[i] * (1 - h*h)
+	}
+
+	dx := make([]float64, len(s.x))
+	dh := make([]float64, len(s.h))
+	if err := c.Gate.backward(s.x, s.h, dPre, dPre, dx, dh); err != nil {
+		return nil, CellState{}, err
+	}
+
+	return dx, CellState{H: dh}, nil
+}
+
+func (c *RNNCell) Params() []Param {
+	return c.Gate.params("gate")
+}
@@ -0,0 +1,50 @@
+package network
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRNNCell(t *testing.T) {
+	t.Run("gradients match finite differences", func(t *testing.T) {
+		// Arrange
+		r := newTestRecurrent(t, func(in, hidden int, rng *rand.Rand) (Cell, error) { return NewRNNCell(in, hidden, rng) })
+
+		// Act
+		report, err := gradCheckSequence(r)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-5))
+	})
+
+	t.Run("outputs stay within tanh range", func(t *testing.T) {
+		// Arrange
+		cell, err := NewRNNCell(2, 3, rand.New(rand.NewPCG(1, 1)))
+		assert.NoError(t, err)
+
+		// Act
+		next, _, err := cell.Step([]float64{50, -50}, cell.ZeroState())
+
+		// Assert
+		assert.NoError(t, err)
+		for _, h := range next.H {
+			assert.LessOrEqual(t, h, 1.0)
+			assert.GreaterOrEqual(t, h, -1.0)
+		}
+	})
+
+	t.Run("rejects a foreign cache", func(t *testing.T) {
+		// Arrange
+		cell, err := NewRNNCell(2, 3, rand.New(rand.NewPCG(1, 1)))
+		assert.NoError(t, err)
+
+		// Act
+		_, _, err = cell.StepBackward(&gruCache{}, CellState{H: make([]float64, 3)})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,90 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Sequential chains layers, feeding the output of each into the next.
+type Sequential struct {
+	Layers []Layer
+}
+
+func NewSequential(layers ...Layer) (*Sequential, error) {
+	if len(layers) == 0 {
+		return nil, fmt.Errorf("sequential must have at least one layer")
+	}
+	for idx, l := range layers {
+		if l == nil {
+			return nil, fmt.Errorf("layer %d is nil", idx)
+		}
+	}
+
+	return &Sequential{Layers: layers}, nil
+}
+
+func (s *Sequential) Forward(x []float64) ([]float64, error) {
+	if len(s.Layers) == 0 {
+		return nil, fmt.Errorf("sequential must have at least one layer")
+	}
+
+	out := x
+	for idx, l := range s.Layers {
+		var err error
+		out, err = l.Forward(out)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("sequential unable to forward layer %d", idx), err)
+		}
+	}
+
+	return out, nil
+}
+
+func (s *Sequential) Backward(dy []float64) ([]float64, error) {
+	grad := dy
+	for idx := len(s.Layers) - 1; idx >= 0; idx-- {
+		var err error
+		grad, err = s.Layers[idx].Backward(grad)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("sequential unable to backward layer %d", idx), err)
+		}
+	}
+
+	return grad, nil
+}
+
+// Params namespaces each layer's params by its index, e.g. "0.W".
+func (s *Sequential) Params() []Param {
+	params := make([]Param, 0)
+	for idx, l := range s.Layers {
+		params = append(params, prefixParams(strconv.Itoa(idx), l.Params())...)
+	}
+
+	return params
+}
+
+// SetTraining switches every layer that has a training mode.
+func (s *Sequential) SetTraining(training bool) {
+	for _, l := range s.Layers {
+		if m, ok := l.(TrainingMode); ok {
+			m.SetTraining(training)
+		}
+	}
+}
+
+// Training reports whether any layer is in training mode.
+func (s *Sequential) Training() bool {
+	return anyTraining(s.Layers...)
+}
+
+// anyTraining reports whether any of layers has a training mode and is in it.
+func anyTraining(layers ...Layer) bool {
+	for _, l := range layers {
+		if m, ok := l.(TrainingMode); ok && m.Training() {
+			return true
+		}
+	}
+
+	return false
+}
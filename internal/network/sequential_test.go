@@ -0,0 +1,99 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequential(t *testing.T) {
+	newSequential := func(t *testing.T, d *Dropout) *Sequential {
+		s, err := NewSequential(
+			&Block{
+				LinearLayer:  LinearLayer{In: 2, Out: 3, W: [][]float64{{0.5, -0.3}, {0.2, 0.8}, {-0.6, 0.4}}, B: []float64{0.1, 0.1, 0.1}},
+				Nonlinearity: ReLU{},
+			},
+			d,
+			&LinearLayer{In: 3, Out: 2, W: [][]float64{{1, -1, 0.5}, {0.3, 0.2, -0.4}}},
+		)
+		assert.NoError(t, err)
+		return s
+	}
+
+	t.Run("Forward matches composing the layers by hand", func(t *testing.T) {
+		// Arrange
+		d, err := NewDropout(0.5, 1)
+		assert.NoError(t, err)
+		s := newSequential(t, d)
+		s.SetTraining(false)
+		x := []float64{1, 2}
+
+		a, err := s.Layers[0].Forward(x)
+		assert.NoError(t, err)
+		expected, err := s.Layers[2].Forward(a)
+		assert.NoError(t, err)
+
+		// Act
+		got, err := s.Forward(x)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("gradients match finite differences in evaluation", func(t *testing.T) {
+		// Arrange
+		d, err := NewDropout(0.5, 1)
+		assert.NoError(t, err)
+		s := newSequential(t, d)
+		s.SetTraining(false)
+
+		// Act
+		report, err := GradCheck(s, []float64{1.3, 2}, GradCheckConfig{Seed: 3})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, report.Check(1e-6))
+	})
+
+	t.Run("Params are namespaced by layer index", func(t *testing.T) {
+		// Arrange
+		d, err := NewDropout(0.5, 1)
+		assert.NoError(t, err)
+		s := newSequential(t, d)
+
+		// Act
+		params := s.Params()
+
+		// Assert
+		assert.Len(t, params, 3)
+		assert.Equal(t, "0.linear.W", params[0].Name)
+		assert.Equal(t, "2.W", params[2].Name)
+	})
+
+	t.Run("SetTraining reaches dropout layers", func(t *testing.T) {
+		// Arrange
+		d, err := NewDropout(0.5, 1)
+		assert.NoError(t, err)
+		s := newSequential(t, d)
+
+		// Act
+		s.SetTraining(false)
+
+		// Assert
+		assert.False(t, d.Training())
+		assert.False(t, s.Training())
+		s.SetTraining(true)
+		assert.True(t, s.Training())
+	})
+
+	t.Run("NewSequential rejects empty and nil layers", func(t *testing.T) {
+		// Act
+		_, errEmpty := NewSequential()
+		_, errNil := NewSequential(&LinearLayer{}, nil)
+
+		// Assert
+		assert.Error(t, errEmpty)
+		assert.Error(t, errNil)
+	})
+}
@@ -0,0 +1,91 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// SoftCrossEntropy is the cross-entropy H(p, softmax(z)) between an arbitrary
+// target distribution p and the distribution implied by logits z. It returns
+// the loss and its gradient with respect to z, which is softmax(z) - p.
+//
+// Unlike CrossEntropy, p need not be one-hot, so it accepts smoothed labels or
+// a teacher's probabilities for distillation.
+func SoftCrossEntropy(p, z []float64) (float64, []float64, error) {
+	if len(p) != len(z) {
+		return 0, nil, fmt.Errorf("p and z must have same length")
+	}
+	if err := validateDistribution(p); err != nil {
+		return 0, nil, errors.Join(errors.New("soft cross entropy: invalid target p"), err)
+	}
+
+	logQ, err := LogSoftmax(z)
+	if err != nil {
+		return 0, nil, errors.Join(errors.New("soft cross entropy: invalid logits z"), err)
+	}
+
+	loss := 0.0
+	grad := make([]float64, len(z))
+	for i := range p {
+		loss -= p[i] * logQ[i]
+		grad[i] = math.Exp(logQ[i]) - p[i]
+	}
+
+	return loss, grad, nil
+}
+
+// KLDivergence computes KL(p || q) = sum p log(p/q) for two probability
+// distributions, using the convention 0 log 0 = 0. The returned gradient is
+// with respect to q. It is an error for q to be zero where p is not, as the
+// divergence is then infinite.
+func KLDivergence(p, q []float64) (float64, []float64, error) {
+	if len(p) != len(q) {
+		return 0, nil, fmt.Errorf("p and q must have same length")
+	}
+	if err := validateDistribution(p); err != nil {
+		return 0, nil, errors.Join(errors.New("kl divergence: invalid p"), err)
+	}
+	if err := validateDistribution(q); err != nil {
+		return 0, nil, errors.Join(errors.New("kl divergence: invalid q"), err)
+	}
+
+	loss := 0.0
+	grad := make([]float64, len(q))
+	for i := range p {
+		if p[i] == 0 {
+			continue
+		}
+		if q[i] == 0 {
+			return 0, nil, fmt.Errorf("kl divergence is infinite: q[%d]=0 where p[%d]=%v", i, i, p[i])
+		}
+		loss += p[i] * math.Log(p[i]/q[i])
+		grad[i] = -p[i] / q[i]
+	}
+
+	return loss, grad, nil
+}
+
+// validateDistribution checks v is a finite, non-negative vector summing to 1.
+func validateDistribution(v []float64) error {
+	if len(v) == 0 {
+		return fmt.Errorf("distribution must have length")
+	}
+
+	sum := 0.0
+	for i, el := range v {
+		if math.IsNaN(el) || math.IsInf(el, 0) {
+			return fmt.Errorf("element %d is NaN/Inf", i)
+		}
+		if el < 0 {
+			return fmt.Errorf("element %d is negative: %v", i, el)
+		}
+		sum += el
+	}
+
+	if math.Abs(sum-1.0) > 1e-6 {
+		return fmt.Errorf("distribution must sum to 1, got %v", sum)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSoftCrossEntropy(t *testing.T) {
+	t.Run("equals NLL when the target is one-hot", func(t *testing.T) {
+		// Arrange
+		z := []float64{0.2, 1.7, -0.4}
+		logP, err := LogSoftmax(z)
+		assert.NoError(t, err)
+
+		// Act
+		loss, _, err := SoftCrossEntropy([]float64{0, 1, 0}, z)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, -logP[1], loss, 1e-12)
+	})
+
+	t.Run("equals KL plus target entropy", func(t *testing.T) {
+		// Arrange
+		p := []float64{0.6, 0.3, 0.1}
+		z := []float64{0.5, 0.1, -1.0}
+		q, _, err := SoftmaxWithStats(z)
+		assert.NoError(t, err)
+		entropy := -(0.6*math.Log(0.6) + 0.3*math.Log(0.3) + 0.1*math.Log(0.1))
+
+		// Act
+		ce, _, err1 := SoftCrossEntropy(p, z)
+		kl, _, err2 := KLDivergence(p, q)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.InDelta(t, kl+entropy, ce, 1e-9)
+	})
+
+	t.Run("gradient matches central differences", func(t *testing.T) {
+		// Arrange
+		p := []float64{0.25, 0.25, 0.5}
+		z := []float64{1.2, -0.3, 0.8}
+		_, grad, err := SoftCrossEntropy(p, z)
+		assert.NoError(t, err)
+		const h = 1e-6
+
+		for i := range z {
+			// Act
+			plus := append([]float64(nil), z...)
+			minus := append([]float64(nil), z...)
+			plus[i] += h
+			minus[i] -= h
+			lp, _, err1 := SoftCrossEntropy(p, plus)
+			lm, _, err2 := SoftCrossEntropy(p, minus)
+
+			// Assert
+			assert.NoError(t, err1)
+			assert.NoError(t, err2)
+			assert.InDelta(t, (lp-lm)/(2*h), grad[i], 1e-6)
+		}
+	})
+
+	t.Run("returns an error if p does not sum to 1", func(t *testing.T) {
+		// Act
+		_, _, err := SoftCrossEntropy([]float64{0.5, 0.6}, []float64{0, 0})
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("returns an error if lengths mismatch", func(t *testing.T) {
+		// Act
+		_, _, err := SoftCrossEntropy([]float64{1}, []float64{0, 0})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestKLDivergence(t *testing.T) {
+	t.Run("is zero for identical distributions", func(t *testing.T) {
+		// Arrange
+		p := []float64{0.2, 0.3, 0.5}
+
+		// Act
+		kl, _, err := KLDivergence(p, p)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.0, kl, 1e-12)
+	})
+
+	t.Run("is asymmetric and positive", func(t *testing.T) {
+		// Arrange
+		p := []float64{0.9, 0.1}
+		q := []float64{0.5, 0.5}
+
+		// Act
+		pq, _, err1 := KLDivergence(p, q)
+		qp, _, err2 := KLDivergence(q, p)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Greater(t, pq, 0.0)
+		assert.Greater(t, qp, 0.0)
+		assert.NotEqual(t, pq, qp)
+		assert.InDelta(t, 0.9*math.Log(1.8)+0.1*math.Log(0.2), pq, 1e-12)
+	})
+
+	t.Run("treats 0 log 0 as 0", func(t *testing.T) {
+		// Act
+		kl, grad, err := KLDivergence([]float64{1, 0}, []float64{0.5, 0.5})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, math.Log(2), kl, 1e-12)
+		assert.InDeltaSlice(t, []float64{-2, 0}, grad, 1e-12)
+	})
+
+	t.Run("gradient with respect to q matches central differences", func(t *testing.T) {
+		// Arrange
+		p := []float64{0.6, 0.3, 0.1}
+		q := []float64{0.2, 0.5, 0.3}
+		_, grad, err := KLDivergence(p, q)
+		assert.NoError(t, err)
+		const h = 1e-7
+
+		// Act
+		// Perturb q directly: the loss is a plain function of q, the
+		// normalisation check tolerates the small step.
+		for i := range q {
+			plus := append([]float64(nil), q...)
+			minus := append([]float64(nil), q...)
+			plus[i] += h
+			minus[i] -= h
+			lp, _, err1 := KLDivergence(p, plus)
+			lm, _, err2 := KLDivergence(p, minus)
+
+			// Assert
+			assert.NoError(t, err1)
+			assert.NoError(t, err2)
+			assert.InDelta(t, (lp-lm)/(2*h), grad[i], 1e-5)
+		}
+	})
+
+	t.Run("returns an error when q is zero where p is not", func(t *testing.T) {
+		// Act
+		_, _, err := KLDivergence([]float64{0.5, 0.5}, []float64{1, 0})
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("returns an error for negative or NaN probabilities", func(t *testing.T) {
+		// Act
+		_, _, errNeg := KLDivergence([]float64{1.5, -0.5}, []float64{0.5, 0.5})
+		_, _, errNaN := KLDivergence([]float64{0.5, 0.5}, []float64{math.NaN(), 0.5})
+
+		// Assert
+		assert.Error(t, errNeg)
+		assert.Error(t, errNaN)
+	})
+}
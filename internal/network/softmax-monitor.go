@@ -0,0 +1,141 @@
+package network
+
+import (
+	"fmt"
+	"math"
+)
+
+// Histogram counts values into equal-width bins over [Min, Max]. Values
+// outside the range are counted in the first or last bin.
+type Histogram struct {
+	Min    float64
+	Max    float64
+	Counts []int
+}
+
+func NewHistogram(min, max float64, bins int) (*Histogram, error) {
+	if bins <= 0 {
+		return nil, fmt.Errorf("histogram must have at least one bin, got %d", bins)
+	}
+	if !(max > min) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return nil, fmt.Errorf("histogram range must be finite with max > min, got [%v, %v]", min, max)
+	}
+
+	return &Histogram{Min: min, Max: max, Counts: make([]int, bins)}, nil
+}
+
+func (h *Histogram) Add(v float64) {
+	bin := int(float64(len(h.Counts)) * (v - h.Min) / (h.Max - h.Min))
+	h.Counts[min(max(bin, 0), len(h.Counts)-1)]++
+}
+
+// BinEdges returns the lower edge of each bin followed by Max.
+func (h *Histogram) BinEdges() []float64 {
+	edges := make([]float64, len(h.Counts)+1)
+	width := (h.Max - h.Min) / float64(len(h.Counts))
+	for i := range h.Counts {
+		edges[i] = h.Min + float64(i)*width
+	}
+	edges[len(h.Counts)] = h.Max
+	return edges
+}
+
+func (h *Histogram) clone() Histogram {
+	return Histogram{Min: h.Min, Max: h.Max, Counts: append([]int(nil), h.Counts...)}
+}
+
+// SoftmaxSnapshot summarises every SoftmaxStats observed since the last Reset.
+type SoftmaxSnapshot struct {
+	Count             int
+	SaturatedFraction float64
+	MinMaxLogit       float64
+	MaxMaxLogit       float64
+	MeanMaxProb       float64
+	MeanEntropy       float64
+	MaxProb           Histogram
+	Entropy           Histogram
+}
+
+// SoftmaxMonitor accumulates SoftmaxStats across many calls so a training loop
+// can report saturation trends, e.g. taking a Snapshot and Reset per epoch.
+type SoftmaxMonitor struct {
+	maxProb *Histogram
+	entropy *Histogram
+
+	count       int
+	saturated   int
+	minMaxLogit float64
+	maxMaxLogit float64
+	sumMaxProb  float64
+	sumEntropy  float64
+}
+
+// NewSoftmaxMonitor creates a monitor for softmax outputs over the given
+// number of classes. MaxProb is binned over [0, 1] and Entropy over
+// [0, log(classes)], its largest possible value.
+func NewSoftmaxMonitor(classes, bins int) (*SoftmaxMonitor, error) {
+	if classes < 2 {
+		return nil, fmt.Errorf("softmax monitor needs at least 2 classes, got %d", classes)
+	}
+
+	maxProb, err := NewHistogram(0, 1, bins)
+	if err != nil {
+		return nil, err
+	}
+	entropy, err := NewHistogram(0, math.Log(float64(classes)), bins)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &SoftmaxMonitor{maxProb: maxProb, entropy: entropy}
+	m.Reset()
+
+	return m, nil
+}
+
+// Observe records the stats of a single SoftmaxWithStats call.
+func (m *SoftmaxMonitor) Observe(stats SoftmaxStats) {
+	m.count++
+	if stats.Saturated {
+		m.saturated++
+	}
+	m.minMaxLogit = min(m.minMaxLogit, stats.MaxLogit)
+	m.maxMaxLogit = max(m.maxMaxLogit, stats.MaxLogit)
+	m.sumMaxProb += stats.MaxProb
+	m.sumEntropy += stats.Entropy
+	m.maxProb.Add(stats.MaxProb)
+	m.entropy.Add(stats.Entropy)
+}
+
+// Snapshot returns a copy of the accumulated statistics. With no observations
+// the means and fractions are 0 and the logit range is [+Inf, -Inf].
+func (m *SoftmaxMonitor) Snapshot() SoftmaxSnapshot {
+	snapshot := SoftmaxSnapshot{
+		Count:       m.count,
+		MinMaxLogit: m.minMaxLogit,
+		MaxMaxLogit: m.maxMaxLogit,
+		MaxProb:     m.maxProb.clone(),
+		Entropy:     m.entropy.clone(),
+	}
+
+	if m.count > 0 {
+		n := float64(m.count)
+		snapshot.SaturatedFraction = float64(m.saturated) / n
+		snapshot.MeanMaxProb = m.sumMaxProb / n
+		snapshot.MeanEntropy = m.sumEntropy / n
+	}
+
+	return snapshot
+}
+
+// Reset clears every accumulated statistic, keeping the bin layout.
+func (m *SoftmaxMonitor) Reset() {
+	m.count = 0
+	m.saturated = 0
+	m.minMaxLogit = math.Inf(1)
+	m.maxMaxLogit = math.Inf(-1)
+	m.sumMaxProb = 0
+	m.sumEntropy = 0
+	clear(m.maxProb.Counts)
+	clear(m.entropy.Counts)
+}
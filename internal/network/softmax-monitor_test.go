@@ -0,0 +1,99 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram(t *testing.T) {
+	t.Run("Add bins values and clamps out of range values", func(t *testing.T) {
+		// Arrange
+		h, err := NewHistogram(0, 1, 4)
+		assert.NoError(t, err)
+
+		// Act
+		for _, v := range []float64{-0.5, 0.1, 0.3, 0.5, 0.99, 1.0, 7} {
+			h.Add(v)
+		}
+
+		// Assert
+		assert.Equal(t, []int{2, 1, 1, 3}, h.Counts)
+		assert.InDeltaSlice(t, []float64{0, 0.25, 0.5, 0.75, 1}, h.BinEdges(), 1e-12)
+	})
+
+	t.Run("NewHistogram rejects invalid ranges and bins", func(t *testing.T) {
+		// Act
+		_, errBins := NewHistogram(0, 1, 0)
+		_, errRange := NewHistogram(1, 1, 3)
+
+		// Assert
+		assert.Error(t, errBins)
+		assert.Error(t, errRange)
+	})
+}
+
+func TestSoftmaxMonitor(t *testing.T) {
+	t.Run("accumulates stats across calls", func(t *testing.T) {
+		// Arrange
+		m, err := NewSoftmaxMonitor(2, 10)
+		assert.NoError(t, err)
+		inputs := [][]float64{{0, 0}, {1, 0}, {40, 0}}
+
+		// Act
+		for _, z := range inputs {
+			_, stats, err := SoftmaxWithStats(z)
+			assert.NoError(t, err)
+			m.Observe(stats)
+		}
+		snapshot := m.Snapshot()
+
+		// Assert
+		assert.Equal(t, 3, snapshot.Count)
+		assert.InDelta(t, 1.0/3, snapshot.SaturatedFraction, 1e-12)
+		assert.InDelta(t, 0.0, snapshot.MinMaxLogit, 1e-12)
+		assert.InDelta(t, 40.0, snapshot.MaxMaxLogit, 1e-12)
+		assert.InDelta(t, (0.5+0.7310585786300049+1)/3, snapshot.MeanMaxProb, 1e-9)
+		assert.Equal(t, []int{0, 0, 0, 0, 0, 1, 0, 1, 0, 1}, snapshot.MaxProb.Counts)
+		// The uniform output has the maximum entropy log(2).
+		assert.Equal(t, 1, snapshot.Entropy.Counts[9])
+	})
+
+	t.Run("Snapshot is a copy and Reset clears the monitor", func(t *testing.T) {
+		// Arrange
+		m, err := NewSoftmaxMonitor(3, 5)
+		assert.NoError(t, err)
+		_, stats, err := SoftmaxWithStats([]float64{1, 2, 3})
+		assert.NoError(t, err)
+		m.Observe(stats)
+		before := m.Snapshot()
+
+		// Act
+		m.Reset()
+		after := m.Snapshot()
+
+		// Assert
+		assert.Equal(t, 1, before.Count)
+		assert.Equal(t, 1, sumCounts(before.MaxProb.Counts))
+		assert.Equal(t, 0, after.Count)
+		assert.Equal(t, 0, sumCounts(after.MaxProb.Counts))
+		assert.True(t, math.IsInf(after.MinMaxLogit, 1))
+	})
+
+	t.Run("NewSoftmaxMonitor rejects fewer than 2 classes", func(t *testing.T) {
+		// Act
+		_, err := NewSoftmaxMonitor(1, 5)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func sumCounts(counts []int) int {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	return total
+}
@@ -13,7 +13,8 @@ type SoftmaxStats struct {
 	MaxLogit  float64
 	MaxProb   float64
 	MinProb   float64
-	Saturated bool // e.g. MaxProb > 1-1e-12
+	Saturated bool    // e.g. MaxProb > 1-1e-12
+	Entropy   float64 // -sum p log p, in nats
 }
 
 const SaturationThreshold = 1 - 1e-5
@@ -69,6 +70,9 @@ func SoftmaxWithStats(z []float64) (p []float64, stats SoftmaxStats, err error)
 		if p > stats.MaxProb {
 			stats.MaxProb = p
 		}
+		if p > 0 {
+			stats.Entropy -= p * math.Log(p)
+		}
 	}
 	stats.Saturated = stats.MaxProb >= SaturationThreshold
 
@@ -153,3 +153,23 @@ func TestSoftmaxErrorHandling(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSoftmaxEntropy(t *testing.T) {
+	t.Run("uniform output has entropy log(n)", func(t *testing.T) {
+		// Act
+		_, stats, err := SoftmaxWithStats([]float64{2, 2, 2, 2})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, math.Log(4), stats.Entropy, 1e-12)
+	})
+
+	t.Run("saturated output has entropy near 0", func(t *testing.T) {
+		// Act
+		_, stats, err := SoftmaxWithStats([]float64{50, 0})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.0, stats.Entropy, 1e-12)
+	})
+}
@@ -0,0 +1,264 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// TransformerBlock is a pre-norm GPT block:
+//
+//	x = x + Attention(AttentionNorm(x))
+//	x = x + FeedForwardOut(FeedForward(FeedForwardNorm(x)))
+//
+// where FeedForward is a LinearLayer and Nonlinearity widening the residual
+// stream and FeedForwardOut projects it back.
+type TransformerBlock struct {
+	AttentionNorm   *LayerNorm
+	Attention       *CausalSelfAttention
+	FeedForwardNorm *LayerNorm
+	FeedForward     *Block
+	FeedForwardOut  *LinearLayer
+}
+
+func NewTransformerBlock(dim, heads, hidden int, nl Nonlinearity, rng *rand.Rand) (*TransformerBlock, error) {
+	attentionNorm, err := NewLayerNorm(dim)
+	if err != nil {
+		return nil, err
+	}
+	attention, err := NewCausalSelfAttention(dim, heads, rng)
+	if err != nil {
+		return nil, err
+	}
+	feedForwardNorm, err := NewLayerNorm(dim)
+	if err != nil {
+		return nil, err
+	}
+	widen, err := NewLinearLayer(dim, hidden, true, HeNormal, rng)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create feed-forward layer"), err)
+	}
+	feedForward, err := NewBlock(*widen, nl)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create feed-forward block"), err)
+	}
+	feedForwardOut, err := NewLinearLayer(hidden, dim, true, XavierUniform, rng)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create feed-forward output layer"), err)
+	}
+
+	return &TransformerBlock{
+		AttentionNorm:   attentionNorm,
+		Attention:       attention,
+		FeedForwardNorm: feedForwardNorm,
+		FeedForward:     feedForward,
+		FeedForwardOut:  feedForwardOut,
+	}, nil
+}
+
+func (b *TransformerBlock) ForwardSequence(X [][]float64) ([][]float64, error) {
+	normed, err := b.AttentionNorm.ForwardBatch(X)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer block unable to normalise attention input"), err)
+	}
+	attended, err := b.Attention.ForwardSequence(normed)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer block unable to attend"), err)
+	}
+	X, err = addResidualBatch(attended, X)
+	if err != nil {
+		return nil, err
+	}
+
+	normed, err = b.FeedForwardNorm.ForwardBatch(X)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer block unable to normalise feed-forward input"), err)
+	}
+	hidden, err := b.FeedForward.ForwardBatch(normed)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer block unable to run feed-forward"), err)
+	}
+	out, err := b.FeedForwardOut.ForwardBatch(hidden)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer block unable to run feed-forward output"), err)
+	}
+
+	return addResidualBatch(out, X)
+}
+
+func (b *TransformerBlock) BackwardSequence(dY [][]float64) ([][]float64, error) {
+	dHidden, err := b.FeedForwardOut.BackwardBatch(dY)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer block unable to backward feed-forward output"), err)
+	}
+	dNormed, err := b.FeedForward.BackwardBatch(dHidden)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer block unable to backward feed-forward"), err)
+	}
+	dFeedForward, err := b.FeedForwardNorm.BackwardBatch(dNormed)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer block unable to backward feed-forward norm"), err)
+	}
+	dY, err = addResidualBatch(dFeedForward, dY)
+	if err != nil {
+		return nil, err
+	}
+
+	dNormed, err = b.Attention.BackwardSequence(dY)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer block unable to backward attention"), err)
+	}
+	dAttention, err := b.AttentionNorm.BackwardBatch(dNormed)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer block unable to backward attention norm"), err)
+	}
+
+	return addResidualBatch(dAttention, dY)
+}
+
+func (b *TransformerBlock) Params() []Param {
+	params := prefixParams("attention_norm", b.AttentionNorm.Params())
+	params = append(params, prefixParams("attention", b.Attention.Params())...)
+	params = append(params, prefixParams("feedforward_norm", b.FeedForwardNorm.Params())...)
+	params = append(params, prefixParams("feedforward", b.FeedForward.Params())...)
+	return append(params, prefixParams("feedforward_out", b.FeedForwardOut.Params())...)
+}
+
+// TransformerConfig describes a small GPT-style language model.
+type TransformerConfig struct {
+	Vocab   int
+	Dim     int
+	Heads   int
+	Layers  int
+	Context int
+	// Hidden is the feed-forward width; 0 means 4·Dim.
+	Hidden int
+	// Activation is the feed-forward nonlinearity; nil means ReLU.
+	Activation Nonlinearity
+	// Sinusoidal selects fixed sinusoidal positions instead of learned ones.
+	Sinusoidal bool
+	Seed       uint64
+}
+
+// Transformer is a decoder-only language model: token and position
+// embeddings, a stack of TransformerBlocks, a final LayerNorm and a Head
+// producing per-position logits for BatchCrossEntropy.
+type Transformer struct {
+	Embedding *Embedding
+	Positions *PositionalEmbedding
+	Blocks    []*TransformerBlock
+	Norm      *LayerNorm
+	Head      *LinearLayer
+}
+
+// NewTransformer builds a Transformer from cfg. Initialisation is fully
+// determined by cfg.Seed.
+func NewTransformer(cfg TransformerConfig) (*Transformer, error) {
+	if cfg.Vocab <= 0 || cfg.Dim <= 0 || cfg.Layers <= 0 || cfg.Context <= 0 {
+		return nil, fmt.Errorf("invalid transformer config: Vocab=%d Dim=%d Layers=%d Context=%d", cfg.Vocab, cfg.Dim, cfg.Layers, cfg.Context)
+	}
+	if cfg.Hidden == 0 {
+		cfg.Hidden = 4 * cfg.Dim
+	}
+	if cfg.Activation == nil {
+		cfg.Activation = ReLU{}
+	}
+
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+
+	embedding, err := NewEmbedding(cfg.Vocab, cfg.Dim, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions *PositionalEmbedding
+	if cfg.Sinusoidal {
+		positions, err = NewSinusoidalPositions(cfg.Context, cfg.Dim)
+	} else {
+		positions, err = NewLearnedPositions(cfg.Context, cfg.Dim, rng)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*TransformerBlock, cfg.Layers)
+	for i := range blocks {
+		blocks[i], err = NewTransformerBlock(cfg.Dim, cfg.Heads, cfg.Hidden, cfg.Activation, rng)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("unable to create transformer block %d", i), err)
+		}
+	}
+
+	norm, err := NewLayerNorm(cfg.Dim)
+	if err != nil {
+		return nil, err
+	}
+	head, err := NewLinearLayer(cfg.Dim, cfg.Vocab, true, XavierUniform, rng)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create transformer head"), err)
+	}
+
+	return &Transformer{Embedding: embedding, Positions: positions, Blocks: blocks, Norm: norm, Head: head}, nil
+}
+
+// ForwardSequence returns the next-token logits at every position of tokens,
+// which must not exceed the context length.
+func (m *Transformer) ForwardSequence(tokens []int) ([][]float64, error) {
+	X, err := m.Embedding.ForwardSequence(tokens)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer unable to embed tokens"), err)
+	}
+	X, err = m.Positions.ForwardSequence(X)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer unable to add positions"), err)
+	}
+
+	for i, b := range m.Blocks {
+		X, err = b.ForwardSequence(X)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("transformer block %d failed", i), err)
+		}
+	}
+
+	X, err = m.Norm.ForwardBatch(X)
+	if err != nil {
+		return nil, errors.Join(errors.New("transformer unable to apply final norm"), err)
+	}
+
+	return m.Head.ForwardBatch(X)
+}
+
+func (m *Transformer) BackwardSequence(dLogits [][]float64) error {
+	dX, err := m.Head.BackwardBatch(dLogits)
+	if err != nil {
+		return errors.Join(errors.New("transformer unable to backward head"), err)
+	}
+	dX, err = m.Norm.BackwardBatch(dX)
+	if err != nil {
+		return errors.Join(errors.New("transformer unable to backward final norm"), err)
+	}
+
+	for i := len(m.Blocks) - 1; i >= 0; i-- {
+		dX, err = m.Blocks[i].BackwardSequence(dX)
+		if err != nil {
+			return errors.Join(fmt.Errorf("transformer block %d backward failed", i), err)
+		}
+	}
+
+	dX, err = m.Positions.BackwardSequence(dX)
+	if err != nil {
+		return errors.Join(errors.New("transformer unable to backward positions"), err)
+	}
+
+	return m.Embedding.BackwardSequence(dX)
+}
+
+func (m *Transformer) Params() []Param {
+	params := prefixParams("embedding", m.Embedding.Params())
+	params = append(params, prefixParams("positions", m.Positions.Params())...)
+	for i, b := range m.Blocks {
+		params = append(params, prefixParams(fmt.Sprintf("blocks.%d", i), b.Params())...)
+	}
+	params = append(params, prefixParams("norm", m.Norm.Params())...)
+	return append(params, prefixParams("head", m.Head.Params())...)
+}
@@ -0,0 +1,116 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/obarker94/ml-doe/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformer(t *testing.T) {
+	cfg := TransformerConfig{Vocab: 4, Dim: 4, Heads: 2, Layers: 2, Context: 5, Hidden: 6, Seed: 11}
+
+	t.Run("is deterministic for a seed", func(t *testing.T) {
+		// Arrange
+		m1, err1 := NewTransformer(cfg)
+		m2, err2 := NewTransformer(cfg)
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+
+		// Act
+		logits1, err1 := m1.ForwardSequence(testTokens)
+		logits2, err2 := m2.ForwardSequence(testTokens)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, logits1, logits2)
+		assert.Len(t, logits1, len(testTokens))
+	})
+
+	for _, sinusoidal := range []bool{false, true} {
+		name := "learned"
+		if sinusoidal {
+			name = "sinusoidal"
+		}
+
+		t.Run("gradients match finite differences with "+name+" positions", func(t *testing.T) {
+			// Arrange
+			c := cfg
+			c.Sinusoidal = sinusoidal
+			m, err := NewTransformer(c)
+			assert.NoError(t, err)
+
+			loss := func() (float64, error) {
+				logits, err := m.ForwardSequence(testTokens)
+				if err != nil {
+					return 0, err
+				}
+				res, err := BatchCrossEntropy(logits, testTargets, CrossEntropyOptions{})
+				return res.Loss, err
+			}
+			backward := func() error {
+				logits, err := m.ForwardSequence(testTokens)
+				if err != nil {
+					return err
+				}
+				res, err := BatchCrossEntropy(logits, testTargets, CrossEntropyOptions{})
+				if err != nil {
+					return err
+				}
+				return m.BackwardSequence(res.Grad)
+			}
+
+			// Act
+			report, err := GradCheckLoss(m.Params(), loss, backward, GradCheckConfig{})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.NoError(t, report.Check(1e-4))
+		})
+	}
+
+	t.Run("learns a Vocab-encoded sequence", func(t *testing.T) {
+		// Arrange
+		vocab, err := internal.NewVocab([]rune("abc."))
+		assert.NoError(t, err)
+		ids, err := vocab.Encode("abcab.")
+		assert.NoError(t, err)
+		m, err := NewTransformer(cfg)
+		assert.NoError(t, err)
+		opt := &Adam{LearningRate: 0.01}
+
+		step := func() float64 {
+			ZeroGrad(m.Params())
+			logits, err := m.ForwardSequence(ids[:5])
+			assert.NoError(t, err)
+			res, err := BatchCrossEntropy(logits, ids[1:], CrossEntropyOptions{})
+			assert.NoError(t, err)
+			assert.NoError(t, m.BackwardSequence(res.Grad))
+			assert.NoError(t, opt.Step(m.Params()))
+			return res.Loss
+		}
+
+		// Act
+		first := step()
+		last := first
+		for range 100 {
+			last = step()
+		}
+
+		// Assert
+		assert.Less(t, last, first/4)
+	})
+
+	t.Run("rejects sequences longer than the context", func(t *testing.T) {
+		// Arrange
+		m, err := NewTransformer(cfg)
+		assert.NoError(t, err)
+
+		// Act
+		_, err = m.ForwardSequence([]int{0, 1, 2, 3, 0, 1})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}